@@ -0,0 +1,104 @@
+package openbank
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// AccountsService handles the accounts the client credential has access to.
+type AccountsService struct {
+	client *Client
+}
+
+// List returns every account the client credential can see.
+func (s *AccountsService) List(ctx context.Context) ([]types.Account, *Response, error) {
+	req, err := s.client.NewAPIRequest(http.MethodGet, "/api/v1/accounts", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var accounts []types.Account
+	resp, err := s.client.Do(req.WithContext(ctx), &accounts, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return accounts, resp, nil
+}
+
+// Get returns the account identified by accountID.
+func (s *AccountsService) Get(ctx context.Context, accountID string) (*types.Account, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s", accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var account types.Account
+	resp, err := s.client.Do(req.WithContext(ctx), &account, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &account, resp, nil
+}
+
+// Balance returns the available, blocked and scheduled balances of the account.
+func (s *AccountsService) Balance(ctx context.Context, accountID string) (*types.Balance, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/balance", accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var balance types.Balance
+	resp, err := s.client.Do(req.WithContext(ctx), &balance, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &balance, resp, nil
+}
+
+// Resolve finds, among the accounts of the client credential, the one with the given account code. Formatting
+// characters such as dots and dashes are ignored. It fails with ErrNotFound when there is no such account.
+func (s *AccountsService) Resolve(ctx context.Context, accountCode string) (*types.Account, *Response, error) {
+	want := onlyDigits(accountCode)
+	if want == "" {
+		var errs validationErrors
+		errs.add("must have digits", "account_code")
+		return nil, nil, errs.err()
+	}
+
+	accounts, resp, err := s.List(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range accounts {
+		if onlyDigits(accounts[i].AccountCode) == want {
+			return &accounts[i], resp, nil
+		}
+	}
+
+	return nil, resp, fmt.Errorf("account %s: %w", accountCode, ErrNotFound)
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,120 @@
+package openbank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const testAccountsBody = `[
+	{"id":"acc-1","branch_code":"0001","account_code":"1234567","owner_document":"12345678000190","status":"active"},
+	{"id":"acc-2","branch_code":"0001","account_code":"7654321","owner_document":"12345678000190","status":"blocked","restrictions":["cash_out"]}
+]`
+
+func TestAccountsService(t *testing.T) {
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/accounts":
+			_, _ = w.Write([]byte(testAccountsBody))
+		case "/api/v1/accounts/acc-1/balance":
+			_, _ = w.Write([]byte(`{"balance":150075,"blocked_balance":2500,"scheduled_balance":0}`))
+		case "/api/v1/accounts/acc-1":
+			_, _ = w.Write([]byte(`{"id":"acc-1","branch_code":"0001","account_code":"1234567","status":"active"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	t.Run("Should list accounts", func(t *testing.T) {
+		accounts, _, err := c.Accounts.List(ctx)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(accounts) != 2 || accounts[1].ID != "acc-2" || !accounts[1].Restricted() {
+			t.Errorf("unexpected accounts %+v", accounts)
+		}
+	})
+
+	t.Run("Should get account by id", func(t *testing.T) {
+		account, _, err := c.Accounts.Get(ctx, "acc-1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if account.AccountCode != "1234567" || account.Restricted() {
+			t.Errorf("unexpected account %+v", account)
+		}
+	})
+
+	t.Run("Should get balance", func(t *testing.T) {
+		balance, _, err := c.Accounts.Balance(ctx, "acc-1")
+		if err != nil {
+			t.Fatalf("Balance() error = %v", err)
+		}
+		if balance.Available != 150075 || balance.Blocked != 2500 || balance.Scheduled != 0 {
+			t.Errorf("unexpected balance %+v", balance)
+		}
+		if balance.Available.String() != "R$ 1.500,75" {
+			t.Errorf("available = %s", balance.Available)
+		}
+	})
+
+	t.Run("Should map missing account to ErrNotFound", func(t *testing.T) {
+		_, _, err := c.Accounts.Get(ctx, "missing")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Should resolve account by formatted code", func(t *testing.T) {
+		account, _, err := c.Accounts.Resolve(ctx, "765432-1")
+		if err != nil || account.ID != "acc-2" {
+			t.Errorf("Resolve() = %+v, %v", account, err)
+		}
+
+		if _, _, err := c.Accounts.Resolve(ctx, "000"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+	t.Run("Should reject empty account id and code", func(t *testing.T) {
+		if _, _, err := c.Accounts.Get(ctx, ""); !errors.Is(err, ErrValidation) {
+			t.Errorf("Get() expected ErrValidation, got %v", err)
+		}
+		if _, _, err := c.Accounts.Resolve(ctx, "-"); !errors.Is(err, ErrValidation) {
+			t.Errorf("Resolve() expected ErrValidation, got %v", err)
+		}
+	})
+}
+
+func TestAPIPath(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		IDs           []string
+		Expected      string
+		ExpectedError bool
+	}{
+		{Name: "Should format ids", IDs: []string{"acc-1", "key-1"}, Expected: "/api/v1/accounts/acc-1/pix_keys/key-1"},
+		{Name: "Should escape ids", IDs: []string{"../x", "a b?c"}, Expected: "/api/v1/accounts/..%2Fx/pix_keys/a%20b%3Fc"},
+		{Name: "Should reject empty id", IDs: []string{"acc-1", ""}, ExpectedError: true},
+		{Name: "Should reject dot segments", IDs: []string{"..", "key-1"}, ExpectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			path, err := apiPath("/api/v1/accounts/%s/pix_keys/%s", testCase.IDs...)
+
+			// Asserts
+			if testCase.ExpectedError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("apiPath() error = %v, expected ErrValidation", err)
+				}
+				return
+			}
+			if err != nil || path != testCase.Expected {
+				t.Errorf("apiPath() = %s, %v, expected %s", path, err, testCase.Expected)
+			}
+		})
+	}
+}
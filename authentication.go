@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -18,22 +19,150 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// tokenRefreshMargin is how long before its expiration an access token is renewed.
+const tokenRefreshMargin = 30 * time.Second
+
+// authCall is an in-flight token request shared by concurrent callers.
+type authCall struct {
+	done  chan struct{}
+	token oauth2.Token
+	err   error
+}
+
+// Authenticate makes sure the client holds a valid access token, requesting a new one when needed. It is safe for
+// concurrent use: callers arriving while a token request is in flight wait for its result instead of issuing another.
+//
+// Calling Authenticate is optional, Do authenticates lazily when the client has credentials configured.
 func (c *Client) Authenticate(sCtx context.Context) error {
-	ctx, span := c.newSpan(sCtx, "openbank auth", trace.SpanKindClient)
-	defer c.endSpan(span)
+	_, err := c.accessToken(sCtx)
+	return err
+}
 
+// accessToken returns a valid token, authenticating at most once for all concurrent callers.
+func (c *Client) accessToken(ctx context.Context) (oauth2.Token, error) {
+	c.m.Lock()
 	if c.validToken() {
-		c.setSpanStatus(span, codes.Ok, "authentication succeeded")
-		return nil
+		token := c.token
+		c.m.Unlock()
+		return token, nil
+	}
+
+	call := c.authCall
+	if call == nil {
+		call = &authCall{done: make(chan struct{})}
+		c.authCall = call
+		// The request is not canceled with the caller that started it, so the other callers still get its token.
+		go c.runAuthCall(context.WithoutCancel(ctx), call)
+	}
+	c.m.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return oauth2.Token{}, ctx.Err()
+	}
+	return call.token, call.err
+}
+
+// runAuthCall requests the token of call and hands it to its callers. A panic is reported to them as an error.
+func (c *Client) runAuthCall(ctx context.Context, call *authCall) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.token, call.err = oauth2.Token{}, fmt.Errorf("openbank: token request panic %v", r)
+			c.log.Error(call.err)
+		}
+
+		c.m.Lock()
+		defer c.m.Unlock()
+		if call.err == nil {
+			c.token = call.token
+		}
+		c.authCall = nil
+		close(call.done)
+	}()
+
+	call.token, call.err = c.requestToken(ctx)
+}
+
+// invalidateToken drops the current token so the next request authenticates again.
+func (c *Client) invalidateToken(accessToken string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.token.AccessToken == accessToken {
+		c.token = oauth2.Token{}
+	}
+}
+
+// canAuthenticate reports whether the client has the credentials needed to request tokens on its own.
+func (c *Client) canAuthenticate() bool {
+	return c.ClientID != "" && c.signer != nil
+}
+
+// requestToken returns a token from the TokenStore when it holds a valid one, otherwise it mints a new token while
+// holding the store lock, so clients sharing the store do not authenticate concurrently.
+func (c *Client) requestToken(ctx context.Context) (oauth2.Token, error) {
+	if c.tokenStore == nil {
+		return c.fetchToken(ctx)
 	}
 
+	key := c.tokenStoreKey()
+	if token, ok := c.storedToken(ctx, key); ok {
+		return token, nil
+	}
+
+	unlock, err := c.tokenStore.Lock(ctx, key)
+	if err != nil {
+		return oauth2.Token{}, fmt.Errorf("locking token store: %w", err)
+	}
+	defer unlock()
+
+	// Another client may have stored a token while we waited for the lock.
+	if token, ok := c.storedToken(ctx, key); ok {
+		return token, nil
+	}
+
+	token, err := c.fetchToken(ctx)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	if err := c.tokenStore.Set(ctx, key, &token); err != nil {
+		c.log.Error(fmt.Errorf("storing token error %s", err))
+	}
+
+	return token, nil
+}
+
+func (c *Client) storedToken(ctx context.Context, key string) (oauth2.Token, bool) {
+	token, err := c.tokenStore.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrTokenNotFound) {
+			c.log.Error(fmt.Errorf("reading stored token error %s", err))
+		}
+		return oauth2.Token{}, false
+	}
+	if token == nil || !c.isValidToken(*token) {
+		return oauth2.Token{}, false
+	}
+	return *token, true
+}
+
+// tokenStoreKey identifies the tokens of this client in the TokenStore.
+func (c *Client) tokenStoreKey() string {
+	return c.AccountURL.Host + "/" + c.ClientID
+}
+
+func (c *Client) fetchToken(sCtx context.Context) (oauth2.Token, error) {
+	ctx, span := c.newSpan(sCtx, "openbank auth", trace.SpanKindClient)
+	defer c.endSpan(span)
+
 	claims := c.authClaims()
 	tokenString, err := c.generateToken(claims)
 	if err != nil {
 		c.setSpanStatus(span, codes.Error, "error generating token")
 		c.spanRecordError(span, err)
 
-		return err
+		return oauth2.Token{}, err
 	}
 
 	data := url.Values{}
@@ -47,7 +176,7 @@ func (c *Client) Authenticate(sCtx context.Context) error {
 		c.setSpanStatus(span, codes.Error, "error parsing URL")
 		c.spanRecordError(span, err)
 
-		return err
+		return oauth2.Token{}, err
 	}
 
 	req, err := http.NewRequest("POST", u.String(), strings.NewReader(data.Encode()))
@@ -55,31 +184,24 @@ func (c *Client) Authenticate(sCtx context.Context) error {
 		c.setSpanStatus(span, codes.Error, "error creating request")
 		c.spanRecordError(span, err)
 
-		return err
+		return oauth2.Token{}, err
 	}
 	req.Header.Add("user-agent", c.UserAgent)
 	req.Header.Add("content-type", "application/x-www-form-urlencoded")
 	req = req.WithContext(ctx)
 
 	var token oauth2.Token
-	_, err = c.Do(req, &token, new(TransferError))
+	_, err = c.do(req, &token, new(TransferError), false)
 	if err != nil {
 		c.setSpanStatus(span, codes.Error, "error executing request")
 		c.spanRecordError(span, err)
 
-		return err
+		return oauth2.Token{}, err
 	}
-	config := &oauth2.Config{}
-	ts := config.TokenSource(ctx, &token)
-
-	c.m.Lock()
-	defer c.m.Unlock()
-	c.client = oauth2.NewClient(ctx, ts)
-	c.token = token
 
 	c.setSpanStatus(span, codes.Ok, "authentication succeeded")
 
-	return nil
+	return token, nil
 }
 
 func (c *Client) authClaims() jwt.MapClaims {
@@ -88,7 +210,7 @@ func (c *Client) authClaims() jwt.MapClaims {
 	claims := jwt.MapClaims{
 		"aud":       u.String(),
 		"client_id": c.ClientID,
-		"exp":       now.Add(time.Hour * time.Duration(2)).Unix(),
+		"exp":       now.Add(c.assertionLifetime).Unix(),
 		"iat":       now.Unix(),
 		"jti":       uuid.New().String(),
 		"iss":       c.ClientID,
@@ -96,15 +218,24 @@ func (c *Client) authClaims() jwt.MapClaims {
 		"realm":     "stone_bank",
 		"sub":       c.ClientID,
 	}
+	for k, v := range c.assertionClaims {
+		claims[k] = v
+	}
 	return claims
 }
 
+// validToken must be called with c.m held.
 func (c *Client) validToken() bool {
-	if !c.token.Valid() {
+	return c.isValidToken(c.token)
+}
+
+// isValidToken reports whether token is usable for longer than tokenRefreshMargin.
+func (c *Client) isValidToken(token oauth2.Token) bool {
+	if !token.Valid() {
 		return false
 	}
 
-	src := strings.Split(c.token.AccessToken, ".")
+	src := strings.Split(token.AccessToken, ".")
 	if len(src) != 3 {
 		return false
 	}
@@ -127,8 +258,8 @@ func (c *Client) validToken() bool {
 	}
 
 	tm := time.Unix(int64(output.Exp), 0)
-	remainder := tm.Sub(time.Now())
-	if remainder < 30 {
+	remainder := time.Until(tm)
+	if remainder < tokenRefreshMargin {
 		return false
 	}
 
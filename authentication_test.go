@@ -0,0 +1,231 @@
+package openbank
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const testTokenPath = "/auth/realms/stone_bank/protocol/openid-connect/token"
+
+// authServer fakes both the Keycloak token endpoint and an API endpoint that requires a bearer token.
+type authServer struct {
+	*httptest.Server
+
+	tokenCalls    atomic.Int32
+	tokenLifetime time.Duration
+	tokenDelay    time.Duration
+}
+
+func newAuthServer(t *testing.T, tokenLifetime time.Duration) *authServer {
+	t.Helper()
+
+	s := &authServer{tokenLifetime: tokenLifetime, tokenDelay: 20 * time.Millisecond}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case testTokenPath:
+			n := s.tokenCalls.Add(1)
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("client_assertion") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			time.Sleep(s.tokenDelay)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": testAccessToken(n, time.Now().Add(s.tokenLifetime)),
+				"token_type":   "Bearer",
+			})
+		default:
+			if len(r.Header.Get("Authorization")) < len("Bearer x") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func testAccessToken(n int32, exp time.Time) string {
+	payload, _ := json.Marshal(map[string]interface{}{"exp": exp.Unix(), "n": n})
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func testPEMPrivateKey(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func newAuthTestClient(t *testing.T, s *authServer) *Client {
+	t.Helper()
+
+	baseURL, _ := SetBaseURL(s.URL)
+	accountURL, _ := SetAccountURL(s.URL)
+	c, err := NewClient(WithClientID("client-id"), WithPEMPrivateKey(testPEMPrivateKey(t)), baseURL, accountURL)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	return c
+}
+
+func TestAuthenticateConcurrentCallsShareOneTokenRequest(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	c := newAuthTestClient(t, s)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.Authenticate(context.Background())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := s.tokenCalls.Load(); got != 1 {
+		t.Errorf("token requests = %d, expected 1", got)
+	}
+}
+
+func TestDoAuthenticatesLazily(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	c := newAuthTestClient(t, s)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req, err := c.NewAPIRequest(http.MethodGet, fmt.Sprintf("/api/v1/resource/%d", i), nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			_, err = c.Do(req, new(responseBody), new(responseBody))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := s.tokenCalls.Load(); got != 1 {
+		t.Errorf("token requests = %d, expected 1", got)
+	}
+}
+
+func TestDoRefreshesTokenBeforeExpiry(t *testing.T) {
+	// Tokens expire inside the refresh margin, so every request must renew it.
+	s := newAuthServer(t, tokenRefreshMargin/2)
+	c := newAuthTestClient(t, s)
+
+	for i := 0; i < 3; i++ {
+		req, _ := c.NewAPIRequest(http.MethodGet, "/api/v1/resource", nil)
+		if _, err := c.Do(req, new(responseBody), new(responseBody)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := s.tokenCalls.Load(); got != 3 {
+		t.Errorf("token requests = %d, expected 3", got)
+	}
+}
+
+func TestAuthenticateWaiterHonorsContext(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	s.tokenDelay = 200 * time.Millisecond
+	c := newAuthTestClient(t, s)
+
+	go func() { _ = c.Authenticate(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Authenticate(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAuthenticateWaitersOutliveCanceledCaller(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	s.tokenDelay = 100 * time.Millisecond
+	c := newAuthTestClient(t, s)
+
+	// The first caller starts the token request and gives up before it finishes.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	canceled := make(chan error, 1)
+	go func() { canceled <- c.Authenticate(ctx) }()
+	time.Sleep(5 * time.Millisecond)
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Errorf("waiter error = %v, expected the token of the shared request", err)
+	}
+	if err := <-canceled; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("canceled caller error = %v, expected context.DeadlineExceeded", err)
+	}
+	if got := s.tokenCalls.Load(); got != 1 {
+		t.Errorf("token requests = %d, expected 1", got)
+	}
+}
+
+// panicTokenStore is a TokenStore whose reads panic.
+type panicTokenStore struct {
+	TokenStore
+}
+
+func (panicTokenStore) Get(context.Context, string) (*oauth2.Token, error) {
+	panic("boom")
+}
+
+func TestAuthenticateRecoversTokenRequestPanic(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	baseURL, _ := SetBaseURL(s.URL)
+	accountURL, _ := SetAccountURL(s.URL)
+	c, err := NewClient(WithClientID("client-id"), WithPEMPrivateKey(testPEMPrivateKey(t)), WithTokenStore(panicTokenStore{}), baseURL, accountURL)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	// Every request fails instead of waiting forever on the request that panicked.
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := c.Authenticate(ctx)
+		cancel()
+		if err == nil || errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Authenticate() attempt %d error = %v, expected the panic as an error", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const testBarcode = "23798641300000010000123060000000052500045670"
+
+func TestBarcodePaymentsValidate(t *testing.T) {
+	var calls int
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body struct {
+			Barcode string `json:"barcode"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/barcode_payments/validate" || body.Barcode != testBarcode {
+			t.Errorf("unexpected request %s with barcode %q", r.URL.Path, body.Barcode)
+		}
+		_, _ = w.Write([]byte(`{"id":"val-1","kind":"boleto","barcode":"` + testBarcode + `","amount":1000,` +
+			`"total_amount":1020,"due_date":"2014-11-20","beneficiary":{"name":"Loja SA"}}`))
+	})
+
+	testCases := []struct {
+		Name          string
+		Code          string
+		ExpectedError bool
+	}{
+		{Name: "Should validate digitable line", Code: "23790.12301 60000.000053 25000.456704 8 64130000001000"},
+		{Name: "Should validate barcode", Code: testBarcode},
+		{Name: "Should reject wrong check digit locally", Code: "23790.12301 60000.000054 25000.456704 8 64130000001000", ExpectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			calls = 0
+
+			// Act
+			validation, _, err := c.BarcodePayments.Validate(context.Background(), "acc-1", testCase.Code)
+
+			// Asserts
+			if testCase.ExpectedError {
+				if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "barcode:") || calls != 0 {
+					t.Errorf("expected local validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if validation.ID != "val-1" || validation.TotalAmount != 1020 || validation.Beneficiary.Name != "Loja SA" {
+				t.Errorf("Validate() = %+v", validation)
+			}
+		})
+	}
+}
+
+func TestBarcodePaymentsPay(t *testing.T) {
+	var calls int
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/barcode_payments" || r.Header.Get(idempotencyHeader) == "" || body["barcode"] != testBarcode {
+			t.Errorf("unexpected request %s with body %v", r.URL.Path, body)
+		}
+		_, _ = w.Write([]byte(`{"id":"pay-1","amount":1020,"status":"SCHEDULED","scheduled_to":"2025-04-10"}`))
+	})
+
+	testCases := []struct {
+		Name          string
+		Input         types.BarcodePaymentInput
+		ExpectedError string
+	}{
+		{
+			Name:  "Should pay digitable line",
+			Input: types.BarcodePaymentInput{AccountID: "acc-1", Barcode: "23790.12301 60000.000053 25000.456704 8 64130000001000", ValidationID: "val-1", Amount: 1020},
+		},
+		{
+			Name:          "Should reject invalid barcode",
+			Input:         types.BarcodePaymentInput{AccountID: "acc-1", Barcode: "123", Amount: 1020},
+			ExpectedError: "barcode",
+		},
+		{
+			Name:          "Should reject non positive amount",
+			Input:         types.BarcodePaymentInput{AccountID: "acc-1", Barcode: testBarcode},
+			ExpectedError: "amount",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			calls = 0
+			input := testCase.Input
+
+			// Act
+			payment, _, err := c.BarcodePayments.Pay(context.Background(), &input)
+
+			// Asserts
+			if testCase.ExpectedError != "" {
+				if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), testCase.ExpectedError+":") || calls != 0 {
+					t.Errorf("expected local validation error on %s, got %v", testCase.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pay() error = %v", err)
+			}
+			if payment.Status != types.PaymentStatusScheduled || input.IdempotencyKey == "" {
+				t.Errorf("Pay() = %+v with idempotency key %q", payment, input.IdempotencyKey)
+			}
+		})
+	}
+}
+
+func TestBarcodePayments(t *testing.T) {
+	var canceled bool
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/barcode_payments/pay-1":
+			_, _ = w.Write([]byte(`{"id":"pay-1","status":"FINISHED","barcode":"` + testBarcode + `"}`))
+		case "/api/v1/barcode_payments/pay-1/cancel":
+			canceled = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	payment, _, err := c.BarcodePayments.Get(ctx, "pay-1")
+	if err != nil || payment.Status != types.PaymentStatusFinished || string(payment.Barcode) != testBarcode {
+		t.Errorf("Get() = %+v, %v", payment, err)
+	}
+
+	if _, err := c.BarcodePayments.Cancel(ctx, "pay-1"); err != nil || !canceled {
+		t.Errorf("Cancel() error = %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package openbank
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// BoletosService handles the boletos issued by the accounts.
+type BoletosService struct {
+	client *Client
+}
+
+// Issue issues a boleto. The input is validated locally first, like due-date PIX charges.
+func (s *BoletosService) Issue(ctx context.Context, input *types.BoletoInput) (*types.Boleto, *Response, error) {
+	var errs validationErrors
+	errs.payment(input.AccountID, input.Amount)
+	if input.DueDate.IsZero() {
+		errs.add("is required", "expiration_date")
+	}
+	if input.LimitDate != nil && input.LimitDate.Before(input.DueDate.Time) {
+		errs.add("must not be before the due date", "limit_date")
+	}
+	errs.payer(input.Payer.Name, input.Payer.Document)
+	errs.dueRules(input.Amount, input.DueDate, input.Fine, input.Interest, input.Discounts)
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	var boleto types.Boleto
+	resp, err := s.client.postIdempotent(ctx, "/api/v1/barcode_payment_invoices", input, &input.IdempotencyKey, &boleto)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &boleto, resp, nil
+}
+
+// Get returns the boleto identified by boletoID.
+func (s *BoletosService) Get(ctx context.Context, boletoID string) (*types.Boleto, *Response, error) {
+	path, err := apiPath("/api/v1/barcode_payment_invoices/%s", boletoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var boleto types.Boleto
+	resp, err := s.client.Do(req.WithContext(ctx), &boleto, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &boleto, resp, nil
+}
+
+// List iterates lazily over the boletos of the account, following the pagination cursor. Iteration stops at the
+// first error.
+func (s *BoletosService) List(ctx context.Context, accountID string, filter types.BoletoFilter) iter.Seq2[types.Boleto, error] {
+	path, err := apiPath("/api/v1/accounts/%s/barcode_payment_invoices", accountID)
+	return paginate(filter.After, func(after string) ([]types.Boleto, string, error) {
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+		return fetchPage[types.Boleto](ctx, s.client, path, boletoQuery(filter))
+	})
+}
+
+// Cancel cancels an unpaid boleto.
+func (s *BoletosService) Cancel(ctx context.Context, boletoID string) (*Response, error) {
+	path, err := apiPath("/api/v1/barcode_payment_invoices/%s/cancel", boletoID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req.WithContext(ctx), nil, nil)
+}
+
+// PDF writes the printable PDF of the boleto to w.
+func (s *BoletosService) PDF(ctx context.Context, boletoID string, w io.Writer) (*Response, error) {
+	path, err := apiPath("/api/v1/barcode_payment_invoices/%s/pdf", boletoID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/pdf")
+
+	return s.client.Do(req.WithContext(ctx), w, nil)
+}
+
+func boletoQuery(filter types.BoletoFilter) url.Values {
+	q := url.Values{}
+	if filter.Status != "" {
+		q.Set("status", filter.Status)
+	}
+	if !filter.Start.IsZero() {
+		q.Set("start_datetime", filter.Start.Format(time.RFC3339))
+	}
+	if !filter.End.IsZero() {
+		q.Set("end_datetime", filter.End.Format(time.RFC3339))
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.After != "" {
+		q.Set("after", filter.After)
+	}
+	return q
+}
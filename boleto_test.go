@@ -0,0 +1,141 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const testBoletoBody = `{"id":"bol-1","amount":150000,"status":"REGISTERED","expiration_date":"2025-04-10",` +
+	`"barcode":"00191961700000123450000001234567891234567890",` +
+	`"writable_line":"00190000090123456789312345678909196170000012345"}`
+
+func TestBoletosIssue(t *testing.T) {
+	var calls int
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/api/v1/barcode_payment_invoices" || r.Header.Get(idempotencyHeader) == "" {
+			t.Errorf("unexpected request %s with idempotency key %q", r.URL.Path, r.Header.Get(idempotencyHeader))
+		}
+		_, _ = w.Write([]byte(testBoletoBody))
+	})
+
+	valid := func() *types.BoletoInput {
+		return &types.BoletoInput{
+			AccountID: "acc-1",
+			Amount:    150000,
+			DueDate:   types.NewDate(2025, time.April, 10),
+			Payer:     types.BoletoPayer{Name: "Cliente SA", Document: "11.222.333/0001-81"},
+			Fine:      &types.ChargeFine{Percentage: 200},
+			Interest:  &types.ChargeInterest{Percentage: 100, Period: types.InterestMonthly},
+			Discounts: []types.ChargeDiscount{{Until: types.NewDate(2025, time.April, 5), Percentage: 500}},
+		}
+	}
+
+	testCases := []struct {
+		Name          string
+		Change        func(*types.BoletoInput)
+		ExpectedError string
+	}{
+		{Name: "Should issue boleto", Change: func(*types.BoletoInput) {}},
+		{Name: "Should require due date", Change: func(i *types.BoletoInput) { i.DueDate = types.Date{} }, ExpectedError: "expiration_date"},
+		{Name: "Should reject limit date before due date", Change: func(i *types.BoletoInput) {
+			limit := types.NewDate(2025, time.April, 1)
+			i.LimitDate = &limit
+		}, ExpectedError: "limit_date"},
+		{Name: "Should validate payer document", Change: func(i *types.BoletoInput) { i.Payer.Document = "11.222.333/0001-00" }, ExpectedError: "payer.document"},
+		{Name: "Should accept alphanumeric CNPJ payer", Change: func(i *types.BoletoInput) { i.Payer.Document = "12.ABC.345/01DE-35" }},
+		{Name: "Should validate interest period", Change: func(i *types.BoletoInput) { i.Interest.Period = "weekly" }, ExpectedError: "interest.period"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			calls = 0
+			input := valid()
+			testCase.Change(input)
+
+			// Act
+			boleto, _, err := c.Boletos.Issue(context.Background(), input)
+
+			// Asserts
+			if testCase.ExpectedError != "" {
+				if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), testCase.ExpectedError+":") || calls != 0 {
+					t.Errorf("expected local validation error on %s, got %v", testCase.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Issue() error = %v", err)
+			}
+			if boleto.Barcode != "00191961700000123450000001234567891234567890" {
+				t.Errorf("barcode = %s", boleto.Barcode)
+			}
+			if got := boleto.DigitableLine.Formatted(); got != "00190.00009 01234.567893 12345.678909 1 96170000012345" {
+				t.Errorf("formatted digitable line = %s", got)
+			}
+		})
+	}
+}
+
+func TestBoletos(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	var canceled bool
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/barcode_payment_invoices/bol-1":
+			_, _ = w.Write([]byte(testBoletoBody))
+		case "/api/v1/barcode_payment_invoices/bol-1/pdf":
+			if r.Header.Get("Accept") != "application/pdf" {
+				t.Errorf("Accept = %s", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write(pdf)
+		case "/api/v1/barcode_payment_invoices/bol-1/cancel":
+			canceled = true
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/v1/accounts/acc-1/barcode_payment_invoices":
+			if r.URL.Query().Get("after") == "" {
+				_, _ = w.Write([]byte(`{"cursor":{"after":"c1"},"data":[{"id":"bol-1"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"cursor":{},"data":[{"id":"bol-2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	boleto, _, err := c.Boletos.Get(ctx, "bol-1")
+	if err != nil || boleto.Status != types.BoletoStatusRegistered || boleto.DueDate.String() != "2025-04-10" {
+		t.Errorf("Get() = %+v, %v", boleto, err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.Boletos.PDF(ctx, "bol-1", &buf); err != nil || !bytes.Equal(buf.Bytes(), pdf) {
+		t.Errorf("PDF() wrote %q, %v", buf.Bytes(), err)
+	}
+
+	var ids []string
+	for boleto, err := range c.Boletos.List(ctx, "acc-1", types.BoletoFilter{Status: types.BoletoStatusPaid}) {
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		ids = append(ids, boleto.ID)
+	}
+	if strings.Join(ids, ",") != "bol-1,bol-2" {
+		t.Errorf("listed %v", ids)
+	}
+
+	if _, err := c.Boletos.Cancel(ctx, "bol-1"); err != nil || !canceled {
+		t.Errorf("Cancel() error = %v", err)
+	}
+}
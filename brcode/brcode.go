@@ -0,0 +1,308 @@
+// Package brcode encodes and decodes PIX BR Codes, the EMV-MPM payloads of PIX QR codes and of "copia e cola" strings
+// defined by the Central Bank of Brazil, and renders them as QR codes. It works offline.
+//
+// Field lengths are counted in bytes.
+package brcode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// PixGUI is the globally unique identifier of the PIX arrangement in the merchant account information.
+const PixGUI = "br.gov.bcb.pix"
+
+// Top level EMV tags.
+const (
+	tagPayloadFormat       = "00"
+	tagPointOfInitiation   = "01"
+	tagMerchantAccount     = "26"
+	tagMerchantCategory    = "52"
+	tagCurrency            = "53"
+	tagAmount              = "54"
+	tagCountry             = "58"
+	tagMerchantName        = "59"
+	tagMerchantCity        = "60"
+	tagPostalCode          = "61"
+	tagAdditionalData      = "62"
+	tagCRC                 = "63"
+	subtagGUI              = "00"
+	subtagKey              = "01"
+	subtagDescription      = "02"
+	subtagURL              = "25"
+	subtagReferenceLabel   = "05"
+	payloadFormatIndicator = "01"
+	currencyBRL            = "986"
+	countryBR              = "BR"
+	noTxID                 = "***"
+	defaultCategoryCode    = "0000"
+)
+
+var (
+	// ErrMalformed is returned when a payload is not a sequence of EMV tag-length-value fields.
+	ErrMalformed = errors.New("brcode: malformed payload")
+	// ErrChecksum is returned when the CRC field is missing or does not match the payload.
+	ErrChecksum = errors.New("brcode: checksum mismatch")
+	// ErrInvalidField is matched by every *FieldError.
+	ErrInvalidField = errors.New("brcode: invalid field")
+
+	txIDRegexp     = regexp.MustCompile(`^[A-Za-z0-9]{1,25}$`)
+	amountRegexp   = regexp.MustCompile(`^\d{1,10}(\.\d{1,2})?$`)
+	categoryRegexp = regexp.MustCompile(`^\d{4}$`)
+)
+
+// FieldError reports an invalid or missing field. It matches ErrInvalidField.
+type FieldError struct {
+	// Tag is the EMV ID of the field, with the ID of its template for nested fields, e.g. "26.01".
+	Tag    string
+	Name   string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("brcode: invalid %s (%s): %s", e.Name, e.Tag, e.Reason)
+}
+
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidField
+}
+
+// BRCode is a PIX BR Code. A static code carries the DICT key of the receiver, a dynamic one the URL of a payload
+// created by the receiver's bank.
+type BRCode struct {
+	// Key is the DICT key of a static code.
+	Key string
+	// Description is a message to the payer, only allowed in static codes.
+	Description string
+	// URL locates the payload of a dynamic code, without the "https://" scheme.
+	URL string
+
+	// Amount is the amount to be paid. When zero the payer chooses it.
+	Amount types.Money
+	// MerchantCategoryCode is the ISO 18245 category of the receiver, "0000" when empty.
+	MerchantCategoryCode string
+	MerchantName         string
+	MerchantCity         string
+	PostalCode           string
+	// TxID identifies the payment for the receiver. Empty means no identifier, encoded as "***".
+	TxID string
+	// SingleUse marks codes that may be paid only once.
+	SingleUse bool
+}
+
+// Dynamic reports whether the code points to a payload URL instead of carrying a key.
+func (c BRCode) Dynamic() bool {
+	return c.URL != ""
+}
+
+// Validate checks the fields against the limits of the BR Code specification.
+func (c BRCode) Validate() error {
+	switch {
+	case c.Key == "" && c.URL == "":
+		return &FieldError{Tag: "26", Name: "merchant account information", Reason: "key or URL is required"}
+	case c.Key != "" && c.URL != "":
+		return &FieldError{Tag: "26", Name: "merchant account information", Reason: "key and URL are mutually exclusive"}
+	case len(c.Key) > 77:
+		return &FieldError{Tag: "26.01", Name: "key", Reason: "longer than 77 bytes"}
+	case c.Description != "" && c.Dynamic():
+		return &FieldError{Tag: "26.02", Name: "description", Reason: "not allowed in dynamic codes"}
+	case len(c.URL) > 77:
+		return &FieldError{Tag: "26.25", Name: "URL", Reason: "longer than 77 bytes"}
+	case strings.Contains(c.URL, "://"):
+		return &FieldError{Tag: "26.25", Name: "URL", Reason: "must not include the scheme"}
+	case len(c.merchantAccount()) > 99:
+		return &FieldError{Tag: "26", Name: "merchant account information", Reason: "longer than 99 bytes"}
+	case c.MerchantCategoryCode != "" && !categoryRegexp.MatchString(c.MerchantCategoryCode):
+		return &FieldError{Tag: tagMerchantCategory, Name: "merchant category code", Reason: "must have 4 digits"}
+	case c.Amount < 0:
+		return &FieldError{Tag: tagAmount, Name: "amount", Reason: "must not be negative"}
+	case len(c.Amount.Decimal()) > 13:
+		return &FieldError{Tag: tagAmount, Name: "amount", Reason: "longer than 13 bytes"}
+	case c.MerchantName == "" || len(c.MerchantName) > 25:
+		return &FieldError{Tag: tagMerchantName, Name: "merchant name", Reason: "must have 1 to 25 bytes"}
+	case c.MerchantCity == "" || len(c.MerchantCity) > 15:
+		return &FieldError{Tag: tagMerchantCity, Name: "merchant city", Reason: "must have 1 to 15 bytes"}
+	case len(c.PostalCode) > 99:
+		return &FieldError{Tag: tagPostalCode, Name: "postal code", Reason: "longer than 99 bytes"}
+	case c.TxID != "" && c.TxID != noTxID && !txIDRegexp.MatchString(c.TxID):
+		return &FieldError{Tag: "62.05", Name: "txid", Reason: "must have 1 to 25 letters or digits"}
+	}
+	return nil
+}
+
+// Encode validates the code and returns its payload, CRC included.
+func (c BRCode) Encode() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	writeField(&b, tagPayloadFormat, payloadFormatIndicator)
+	if c.SingleUse {
+		writeField(&b, tagPointOfInitiation, "12")
+	}
+	writeField(&b, tagMerchantAccount, c.merchantAccount())
+	writeField(&b, tagMerchantCategory, orDefault(c.MerchantCategoryCode, defaultCategoryCode))
+	writeField(&b, tagCurrency, currencyBRL)
+	if c.Amount > 0 {
+		writeField(&b, tagAmount, c.Amount.Decimal())
+	}
+	writeField(&b, tagCountry, countryBR)
+	writeField(&b, tagMerchantName, c.MerchantName)
+	writeField(&b, tagMerchantCity, c.MerchantCity)
+	if c.PostalCode != "" {
+		writeField(&b, tagPostalCode, c.PostalCode)
+	}
+
+	var additional strings.Builder
+	writeField(&additional, subtagReferenceLabel, orDefault(c.TxID, noTxID))
+	writeField(&b, tagAdditionalData, additional.String())
+
+	b.WriteString(tagCRC + "04")
+	b.WriteString(checksum(b.String()))
+	return b.String(), nil
+}
+
+// String returns the payload, or an empty string when the code is invalid.
+func (c BRCode) String() string {
+	s, _ := c.Encode()
+	return s
+}
+
+func (c BRCode) merchantAccount() string {
+	var b strings.Builder
+	writeField(&b, subtagGUI, PixGUI)
+	if c.Key != "" {
+		writeField(&b, subtagKey, c.Key)
+	}
+	if c.Description != "" {
+		writeField(&b, subtagDescription, c.Description)
+	}
+	if c.URL != "" {
+		writeField(&b, subtagURL, c.URL)
+	}
+	return b.String()
+}
+
+func writeField(b *strings.Builder, tag, value string) {
+	b.WriteString(tag)
+	b.WriteString(fmt.Sprintf("%02d", len(value)))
+	b.WriteString(value)
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// Decode parses and validates a BR Code payload. Surrounding white space is ignored.
+func Decode(payload string) (*BRCode, error) {
+	payload = strings.TrimSpace(payload)
+
+	// The CRC field is always the last one and covers everything before its value, its own tag and length included.
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != tagCRC+"04" {
+		return nil, fmt.Errorf("%w: missing CRC field", ErrChecksum)
+	}
+	if got, want := strings.ToUpper(payload[len(payload)-4:]), checksum(payload[:len(payload)-4]); got != want {
+		return nil, fmt.Errorf("%w: got %s, computed %s", ErrChecksum, got, want)
+	}
+
+	fields, err := parseFields(payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload[:2] != tagPayloadFormat || fields[tagPayloadFormat] != payloadFormatIndicator {
+		return nil, &FieldError{Tag: tagPayloadFormat, Name: "payload format indicator", Reason: `must be the first field, with value "01"`}
+	}
+
+	var c BRCode
+	switch fields[tagPointOfInitiation] {
+	case "", "11":
+	case "12":
+		c.SingleUse = true
+	default:
+		return nil, &FieldError{Tag: tagPointOfInitiation, Name: "point of initiation method", Reason: `must be "11" or "12"`}
+	}
+
+	account, ok := fields[tagMerchantAccount]
+	if !ok {
+		return nil, &FieldError{Tag: tagMerchantAccount, Name: "merchant account information", Reason: "missing"}
+	}
+	sub, err := parseFields(account)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(sub[subtagGUI], PixGUI) {
+		return nil, &FieldError{Tag: "26.00", Name: "GUI", Reason: "must be " + PixGUI}
+	}
+	c.Key, c.Description, c.URL = sub[subtagKey], sub[subtagDescription], sub[subtagURL]
+
+	c.MerchantCategoryCode = fields[tagMerchantCategory]
+	if c.MerchantCategoryCode == "" {
+		return nil, &FieldError{Tag: tagMerchantCategory, Name: "merchant category code", Reason: "missing"}
+	}
+	if fields[tagCurrency] != currencyBRL {
+		return nil, &FieldError{Tag: tagCurrency, Name: "transaction currency", Reason: "must be " + currencyBRL}
+	}
+	if v, ok := fields[tagAmount]; ok {
+		if !amountRegexp.MatchString(v) {
+			return nil, &FieldError{Tag: tagAmount, Name: "amount", Reason: fmt.Sprintf("invalid amount %q", v)}
+		}
+		c.Amount, _ = types.ParseMoney(v)
+	}
+	if fields[tagCountry] != countryBR {
+		return nil, &FieldError{Tag: tagCountry, Name: "country code", Reason: "must be " + countryBR}
+	}
+	c.MerchantName = fields[tagMerchantName]
+	c.MerchantCity = fields[tagMerchantCity]
+	c.PostalCode = fields[tagPostalCode]
+
+	if additional, ok := fields[tagAdditionalData]; ok {
+		sub, err := parseFields(additional)
+		if err != nil {
+			return nil, err
+		}
+		if c.TxID = sub[subtagReferenceLabel]; c.TxID == noTxID {
+			c.TxID = ""
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// parseFields splits s into its tag-length-value fields. Duplicated tags are rejected.
+func parseFields(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for i := 0; i < len(s); {
+		if len(s)-i < 4 {
+			return nil, fmt.Errorf("%w: truncated field at offset %d", ErrMalformed, i)
+		}
+		tag := s[i : i+2]
+		if tag[0] < '0' || tag[0] > '9' || tag[1] < '0' || tag[1] > '9' {
+			return nil, fmt.Errorf("%w: invalid tag %q at offset %d", ErrMalformed, tag, i)
+		}
+		n, err := strconv.Atoi(s[i+2 : i+4])
+		if err != nil || n < 0 || s[i+2] == '+' || s[i+2] == '-' {
+			return nil, fmt.Errorf("%w: invalid length of field %s", ErrMalformed, tag)
+		}
+		if i+4+n > len(s) {
+			return nil, fmt.Errorf("%w: field %s exceeds the payload", ErrMalformed, tag)
+		}
+		if _, dup := fields[tag]; dup {
+			return nil, fmt.Errorf("%w: duplicated field %s", ErrMalformed, tag)
+		}
+		fields[tag] = s[i+4 : i+4+n]
+		i += 4 + n
+	}
+	return fields, nil
+}
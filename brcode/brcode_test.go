@@ -0,0 +1,157 @@
+package brcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// bacenExample is the static BR Code example of the BR Code manual of the Central Bank.
+const bacenExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestCRC16(t *testing.T) {
+	if got := crc16("123456789"); got != 0x29B1 {
+		t.Errorf("crc16() = %04X, expected 29B1", got)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Code     BRCode
+		Expected string
+	}{
+		{
+			Name:     "Should encode the BACEN example",
+			Code:     BRCode{Key: "123e4567-e12b-12d1-a456-426655440000", MerchantName: "Fulano de Tal", MerchantCity: "BRASILIA"},
+			Expected: bacenExample,
+		},
+		{
+			Name: "Should encode amount, description and txid",
+			Code: BRCode{Key: "fulano@example.com", Description: "Pedido 42", Amount: 1050, MerchantName: "Fulano", MerchantCity: "SAO PAULO", TxID: "PEDIDO42"},
+			Expected: "00020126530014br.gov.bcb.pix0118fulano@example.com0209Pedido 42" +
+				"520400005303986540510.505802BR5906Fulano6009SAO PAULO62120508PEDIDO426304258A",
+		},
+		{
+			Name: "Should encode single use dynamic code",
+			Code: BRCode{URL: "pix.example.com/qr/v2/9d36b84f", MerchantName: "Loja", MerchantCity: "RECIFE", SingleUse: true},
+			Expected: "00020101021226520014br.gov.bcb.pix2530pix.example.com/qr/v2/9d36b84f" +
+				"5204000053039865802BR5904Loja6006RECIFE62070503***630412C6",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			got, err := testCase.Code.Encode()
+
+			// Asserts
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got != testCase.Expected {
+				t.Errorf("Encode() = %s, expected %s", got, testCase.Expected)
+			}
+
+			decoded, err := Decode(got)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			expected := testCase.Code
+			expected.MerchantCategoryCode = defaultCategoryCode
+			if *decoded != expected {
+				t.Errorf("Decode() = %#v, expected %#v", *decoded, expected)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	withCRC := func(s string) string {
+		return s + "6304" + checksum(s+"6304")
+	}
+	withoutCRC := strings.TrimSuffix(bacenExample, "63041D3D")
+
+	testCases := []struct {
+		Name        string
+		Payload     string
+		ExpectedErr error
+		ExpectedTag string
+	}{
+		{Name: "Should decode the BACEN example", Payload: bacenExample},
+		{Name: "Should ignore surrounding white space and lowercase CRC", Payload: " " + withoutCRC + "63041d3d\n"},
+		{Name: "Should reject checksum mismatch", Payload: strings.Replace(bacenExample, "Fulano", "Ciclano", 1), ExpectedErr: ErrChecksum},
+		{Name: "Should reject missing checksum", Payload: withoutCRC, ExpectedErr: ErrChecksum},
+		{Name: "Should reject truncated field", Payload: withCRC("000201265"), ExpectedErr: ErrMalformed},
+		{Name: "Should reject field exceeding payload", Payload: withCRC("00020126990014br.gov.bcb.pix"), ExpectedErr: ErrMalformed},
+		{Name: "Should reject duplicated field", Payload: withCRC(withoutCRC + "5802BR"), ExpectedErr: ErrMalformed},
+		{Name: "Should require payload format first", Payload: withCRC(strings.TrimPrefix(withoutCRC, "000201") + "000201"), ExpectedTag: "00"},
+		{Name: "Should require PIX GUI", Payload: withCRC(strings.Replace(withoutCRC, "br.gov.bcb.pix", "br.gov.bcb.xyz", 1)), ExpectedTag: "26.00"},
+		{Name: "Should require BRL", Payload: withCRC(strings.Replace(withoutCRC, "5303986", "5303840", 1)), ExpectedTag: "53"},
+		{Name: "Should reject malformed amount", Payload: withCRC(strings.Replace(withoutCRC, "5802BR", "54041,005802BR", 1)), ExpectedTag: "54"},
+		{Name: "Should reject long merchant city", Payload: withCRC(strings.Replace(withoutCRC, "6008BRASILIA", "6017BRASILIA DO NORTE", 1)), ExpectedTag: "60"},
+		{Name: "Should reject invalid txid", Payload: withCRC(strings.Replace(withoutCRC, "62070503***", "62070503a-b", 1)), ExpectedTag: "62.05"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			code, err := Decode(testCase.Payload)
+
+			// Asserts
+			var fieldErr *FieldError
+			switch {
+			case testCase.ExpectedErr != nil:
+				if !errors.Is(err, testCase.ExpectedErr) {
+					t.Errorf("Decode() error = %v, expected %v", err, testCase.ExpectedErr)
+				}
+			case testCase.ExpectedTag != "":
+				if !errors.As(err, &fieldErr) || fieldErr.Tag != testCase.ExpectedTag || !errors.Is(err, ErrInvalidField) {
+					t.Errorf("Decode() error = %v, expected invalid field %s", err, testCase.ExpectedTag)
+				}
+			case err != nil:
+				t.Errorf("Decode() error = %v", err)
+			case code.Key != "123e4567-e12b-12d1-a456-426655440000" || code.MerchantName != "Fulano de Tal" || code.TxID != "":
+				t.Errorf("Decode() = %+v", code)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := BRCode{Key: "fulano@example.com", MerchantName: "Fulano", MerchantCity: "RECIFE"}
+
+	testCases := []struct {
+		Name        string
+		Change      func(*BRCode)
+		ExpectedTag string
+	}{
+		{Name: "Should require key or URL", Change: func(c *BRCode) { c.Key = "" }, ExpectedTag: "26"},
+		{Name: "Should reject key and URL", Change: func(c *BRCode) { c.URL = "pix.example.com/1" }, ExpectedTag: "26"},
+		{Name: "Should reject URL with scheme", Change: func(c *BRCode) { c.Key, c.URL = "", "https://pix.example.com/1" }, ExpectedTag: "26.25"},
+		{Name: "Should reject description in dynamic code", Change: func(c *BRCode) { c.Key, c.URL, c.Description = "", "pix.example.com/1", "x" }, ExpectedTag: "26.02"},
+		{Name: "Should reject long merchant account", Change: func(c *BRCode) { c.Description = strings.Repeat("d", 60) }, ExpectedTag: "26"},
+		{Name: "Should reject negative amount", Change: func(c *BRCode) { c.Amount = -1 }, ExpectedTag: "54"},
+		{Name: "Should reject malformed category", Change: func(c *BRCode) { c.MerchantCategoryCode = "12" }, ExpectedTag: "52"},
+		{Name: "Should require merchant name", Change: func(c *BRCode) { c.MerchantName = "" }, ExpectedTag: "59"},
+		{Name: "Should reject long merchant name", Change: func(c *BRCode) { c.MerchantName = strings.Repeat("n", 26) }, ExpectedTag: "59"},
+		{Name: "Should reject long txid", Change: func(c *BRCode) { c.TxID = strings.Repeat("t", 26) }, ExpectedTag: "62.05"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			code := valid
+			testCase.Change(&code)
+
+			// Act
+			_, err := code.Encode()
+
+			// Asserts
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Tag != testCase.ExpectedTag {
+				t.Errorf("Encode() error = %v, expected invalid field %s", err, testCase.ExpectedTag)
+			}
+		})
+	}
+}
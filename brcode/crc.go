@@ -0,0 +1,25 @@
+package brcode
+
+import "fmt"
+
+// crc16 computes the CRC16-CCITT-FALSE checksum (polynomial 0x1021, initial value 0xFFFF) used by the EMV QR code
+// specification.
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// checksum returns the value of the CRC field for a payload ending with the "6304" CRC field header.
+func checksum(payload string) string {
+	return fmt.Sprintf("%04X", crc16(payload))
+}
@@ -0,0 +1,51 @@
+package brcode
+
+import (
+	"testing"
+)
+
+func FuzzDecode(f *testing.F) {
+	f.Add(bacenExample)
+	f.Add("00020101021226520014br.gov.bcb.pix2530pix.example.com/qr/v2/9d36b84f5204000053039865802BR5904Loja6006RECIFE62070503***630412C6")
+	f.Add("000201")
+	f.Add("6304")
+
+	f.Fuzz(func(t *testing.T, payload string) {
+		code, err := Decode(payload)
+		if err != nil {
+			return
+		}
+
+		// Every decoded code must encode back to a payload that decodes to the same code.
+		encoded, err := code.Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v for decoded %#v", err, code)
+		}
+		again, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(Encode()) error = %v", err)
+		}
+		if *again != *code {
+			t.Fatalf("round trip changed %#v into %#v", code, again)
+		}
+	})
+}
+
+func FuzzQRCode(f *testing.F) {
+	f.Add(bacenExample, 1)
+	f.Add("", 0)
+	f.Add("\x00\xff", 3)
+
+	f.Fuzz(func(t *testing.T, payload string, level int) {
+		if len(payload) > 300 {
+			return
+		}
+		q, err := NewQRCode(payload, ErrorCorrection(level&3))
+		if err != nil {
+			t.Fatalf("NewQRCode() error = %v", err)
+		}
+		if gotLevel, got := readQRCode(t, q); got != payload || gotLevel != ErrorCorrection(level&3) {
+			t.Fatalf("read %q at level %d", got, gotLevel)
+		}
+	})
+}
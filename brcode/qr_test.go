@@ -0,0 +1,231 @@
+package brcode
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatAndVersionBits(t *testing.T) {
+	// Values from the tables of ISO/IEC 18004, annexes C and D.
+	if got := formatBits(Medium, 0); got != 0x5412 {
+		t.Errorf("formatBits(Medium, 0) = %015b", got)
+	}
+	if got := formatBits(Low, 0); got != 0x77C4 {
+		t.Errorf("formatBits(Low, 0) = %015b", got)
+	}
+	if got := formatBits(High, 7); got != 0x083B {
+		t.Errorf("formatBits(High, 7) = %015b", got)
+	}
+	if got := versionBits(7); got != 0x07C94 {
+		t.Errorf("versionBits(7) = %018b", got)
+	}
+	if got := alignmentPositions(32); !reflect.DeepEqual(got, []int{6, 34, 60, 86, 112, 138}) {
+		t.Errorf("alignmentPositions(32) = %v", got)
+	}
+}
+
+func TestNewQRCode(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Payload         string
+		Level           ErrorCorrection
+		ExpectedVersion int
+	}{
+		{Name: "Should fit short payload in version 1", Payload: "PIX", Level: Medium, ExpectedVersion: 1},
+		{Name: "Should encode the BACEN example", Payload: bacenExample, Level: Medium, ExpectedVersion: 8},
+		{Name: "Should encode with low correction", Payload: bacenExample, Level: Low, ExpectedVersion: 7},
+		{Name: "Should encode with high correction", Payload: bacenExample, Level: High, ExpectedVersion: 11},
+		{Name: "Should encode large payloads in several blocks", Payload: strings.Repeat("0123456789", 60), Level: Quartile, ExpectedVersion: 23},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			q, err := NewQRCode(testCase.Payload, testCase.Level)
+
+			// Asserts
+			if err != nil {
+				t.Fatalf("NewQRCode() error = %v", err)
+			}
+			if q.Version() != testCase.ExpectedVersion || q.Size() != testCase.ExpectedVersion*4+17 {
+				t.Errorf("version = %d, size = %d", q.Version(), q.Size())
+			}
+			level, payload := readQRCode(t, q)
+			if level != testCase.Level || payload != testCase.Payload {
+				t.Errorf("read level %d payload %q", level, payload)
+			}
+		})
+	}
+
+	if _, err := NewQRCode(strings.Repeat("x", 3000), Medium); err != ErrPayloadTooLong {
+		t.Errorf("expected ErrPayloadTooLong, got %v", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	q, err := BRCode{Key: "fulano@example.com", MerchantName: "Fulano", MerchantCity: "RECIFE"}.QRCode()
+	if err != nil {
+		t.Fatalf("QRCode() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := q.WritePNG(&buf, 4, QuietZone); err != nil {
+		t.Fatalf("WritePNG() error = %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	side := (q.Size() + 2*QuietZone) * 4
+	if img.Bounds().Dx() != side {
+		t.Errorf("PNG width = %d, expected %d", img.Bounds().Dx(), side)
+	}
+	// The top left module of the finder pattern is dark, the quiet zone light.
+	if r, _, _, _ := img.At(QuietZone*4, QuietZone*4).RGBA(); r != 0 {
+		t.Error("finder pattern should be dark")
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r == 0 {
+		t.Error("quiet zone should be light")
+	}
+
+	buf.Reset()
+	if err := q.WriteSVG(&buf, QuietZone); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+	svg := buf.String()
+	if !strings.Contains(svg, `viewBox="0 0 53 53"`) || !strings.Contains(svg, "M4,4h1v1h-1z") {
+		t.Errorf("unexpected SVG %s", svg)
+	}
+}
+
+// readQRCode decodes a symbol built by NewQRCode, checking its format information and error correction codewords.
+func readQRCode(t *testing.T, q *QRCode) (ErrorCorrection, string) {
+	t.Helper()
+
+	var format int
+	for i := 0; i <= 5; i++ {
+		format |= bit(q.Dark(8, i)) << i
+	}
+	format |= bit(q.Dark(8, 7))<<6 | bit(q.Dark(8, 8))<<7 | bit(q.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		format |= bit(q.Dark(14-i, 8)) << i
+	}
+
+	level, mask := ErrorCorrection(-1), -1
+	for l := Low; l <= High; l++ {
+		for m := 0; m < 8; m++ {
+			if formatBits(l, m) == format {
+				level, mask = l, m
+			}
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("invalid format information %015b", format)
+	}
+
+	unmasked := newQRCode(q.version)
+	unmasked.drawFunctionPatterns()
+	for y := range q.modules {
+		for x := range q.modules[y] {
+			if !unmasked.function[y][x] {
+				unmasked.modules[y][x] = q.modules[y][x]
+			}
+		}
+	}
+	unmasked.applyMask(mask)
+
+	// Read the codewords back in placement order.
+	raw := make([]byte, numRawDataModules(q.version)/8)
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !unmasked.function[y][x] && i < len(raw)*8 {
+					raw[i/8] |= byte(bit(unmasked.modules[y][x])) << (7 - i%8)
+					i++
+				}
+			}
+		}
+	}
+
+	// De-interleave the blocks and check their Reed-Solomon syndromes.
+	numBlocks := eccBlocks[level][q.version]
+	eccLen := eccCodewordsPerBlock[level][q.version]
+	numShort := numBlocks - len(raw)%numBlocks
+	shortDataLen := len(raw)/numBlocks - eccLen
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for col := 0; col <= shortDataLen; col++ {
+		for b := range blocks {
+			if col < shortDataLen || b >= numShort {
+				blocks[b] = append(blocks[b], raw[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for b := range blocks {
+		data = append(data, blocks[b]...)
+	}
+	for col := 0; col < eccLen; col++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], raw[k])
+			k++
+		}
+	}
+	for b, block := range blocks {
+		root := byte(1)
+		for n := 0; n < eccLen; n++ {
+			var s byte
+			for _, c := range block {
+				s = gfMultiply(s, root) ^ c
+			}
+			if s != 0 {
+				t.Fatalf("block %d has non-zero syndrome %d", b, n)
+			}
+			root = gfMultiply(root, 2)
+		}
+	}
+
+	// Parse the byte mode segment.
+	bits := bitReader{data: data}
+	if mode := bits.read(4); mode != 0x4 {
+		t.Fatalf("mode = %x, expected byte mode", mode)
+	}
+	payload := make([]byte, bits.read(countBits(q.version)))
+	for n := range payload {
+		payload[n] = byte(bits.read(8))
+	}
+	return level, string(payload)
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(n int) int {
+	v := 0
+	for ; n > 0; n-- {
+		v = v<<1 | int(r.data[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
+
+func bit(dark bool) int {
+	if dark {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package brcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// QuietZone is the number of light modules around a symbol recommended by the specification.
+const QuietZone = 4
+
+// QRCode encodes the code as a QR code with Medium error correction, as recommended for PIX.
+func (c BRCode) QRCode() (*QRCode, error) {
+	payload, err := c.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return NewQRCode(payload, Medium)
+}
+
+// Image returns the symbol as a grayscale image with scale pixels per module and a quiet zone of border modules.
+func (q *QRCode) Image(scale, border int) image.Image {
+	scale, border = max(scale, 1), max(border, 0)
+	side := (q.size + 2*border) * scale
+
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for py := 0; py < side; py++ {
+		for px := 0; px < side; px++ {
+			c := color.Gray{Y: 0xFF}
+			if q.Dark(px/scale-border, py/scale-border) {
+				c.Y = 0
+			}
+			img.SetGray(px, py, c)
+		}
+	}
+	return img
+}
+
+// WritePNG writes the symbol as a PNG image with scale pixels per module and a quiet zone of border modules.
+func (q *QRCode) WritePNG(w io.Writer, scale, border int) error {
+	return png.Encode(w, q.Image(scale, border))
+}
+
+// WriteSVG writes the symbol as an SVG image, one user unit per module, with a quiet zone of border modules.
+func (q *QRCode) WriteSVG(w io.Writer, border int) error {
+	border = max(border, 0)
+	side := q.size + 2*border
+
+	var path strings.Builder
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+border, y+border)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">
+<rect width="100%%" height="100%%" fill="#FFFFFF"/>
+<path d="%[2]s" fill="#000000"/>
+</svg>
+`, side, path.String())
+	return err
+}
@@ -3,6 +3,7 @@ package openbank
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/rsa"
 	"encoding/json"
 	"errors"
@@ -13,11 +14,12 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
 	"go.opentelemetry.io/otel/trace"
@@ -53,14 +55,40 @@ type Client struct {
 
 	privateKeyData []byte // used to build privateKey
 	privateKey     *rsa.PrivateKey
+	signer         crypto.Signer    // signs client assertions, defaults to privateKey
+	decrypter      crypto.Decrypter // decrypts webhooks, defaults to privateKey or to an RSA signer
+
+	signingAlg        string
+	signingMethod     *signerMethod
+	keyID             string
+	assertionLifetime time.Duration
+	assertionClaims   map[string]interface{}
 
 	Sandbox bool
 
 	UserAgent string
 
-	token oauth2.Token
+	token      oauth2.Token
+	authCall   *authCall
+	tokenStore TokenStore
 
 	otelTracer trace.Tracer
+
+	retryPolicy *RetryPolicy
+
+	jwks *jwksCache
+
+	webhookEvents EventStore
+	webhookWindow time.Duration
+
+	Accounts   *AccountsService
+	Statements *StatementsService
+	Transfers  *TransfersService
+	Pix        *PixService
+	PixKeys    *PixKeysService
+	Boletos    *BoletosService
+
+	BarcodePayments *BarcodePaymentsService
 }
 
 func NewClient(opts ...ClientOpt) (*Client, error) {
@@ -76,21 +104,46 @@ func NewClient(opts ...ClientOpt) (*Client, error) {
 		SiteURL:         siteURL,
 		StonePublicKeys: make(types.StonePublicKeys),
 		m:               &sync.Mutex{},
+		jwks:            &jwksCache{},
+
+		assertionLifetime: defaultAssertionLifetime,
 	}
 
 	c.ApplyOpts(opts...)
 
+	c.Accounts = &AccountsService{client: &c}
+	c.Statements = &StatementsService{client: &c}
+	c.Transfers = &TransfersService{client: &c}
+	c.Pix = &PixService{client: &c}
+	c.PixKeys = &PixKeysService{client: &c}
+	c.Boletos = &BoletosService{client: &c}
+	c.BarcodePayments = &BarcodePaymentsService{client: &c}
+
 	if len(c.privateKeyData) > 0 {
-		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(c.privateKeyData)
+		privateKey, err := parsePEMPrivateKey(c.privateKeyData)
 		if err != nil {
 			return nil, err
 		}
 
-		if privateKey == nil {
-			return nil, fmt.Errorf("invalid private key")
+		if rsaKey, ok := privateKey.(*rsa.PrivateKey); ok {
+			c.privateKey = rsaKey
 		}
+		if c.signer == nil {
+			c.signer = privateKey
+		}
+	}
 
-		c.privateKey = privateKey
+	if err := c.setupSigning(); err != nil {
+		return nil, err
+	}
+	if err := c.setupDecryption(); err != nil {
+		return nil, err
+	}
+
+	if c.jwks.file != "" {
+		if err := c.LoadJWKSFile(c.jwks.file); err != nil {
+			return nil, err
+		}
 	}
 
 	// Set log
@@ -205,18 +258,21 @@ type ErrorResponse struct {
 	RequestID string `json:"request_id"`
 
 	TransferError interface{} `json:"transfer_error"`
+
+	// details holds the body decoded as TransferError when it matches that shape.
+	details *TransferError
 }
 
 type TransferError struct {
-	Type             string `json:"type,omitempty"`
-	ValidationErrors []struct {
-		Error string   `json:"error,omitempty"`
-		Path  []string `json:"path,omitempty"`
-	} `json:"validation_errors,omitempty"`
-	Reason []struct {
-		Error string   `json:"error,omitempty"`
-		Path  []string `json:"path,omitempty"`
-	} `json:"reason,omitempty"`
+	Type             string        `json:"type,omitempty"`
+	ValidationErrors []ErrorDetail `json:"validation_errors,omitempty"`
+	Reason           []ErrorDetail `json:"reason,omitempty"`
+}
+
+// ErrorDetail points to the request field that caused an error.
+type ErrorDetail struct {
+	Error string   `json:"error,omitempty"`
+	Path  []string `json:"path,omitempty"`
 }
 
 func (r *ErrorResponse) Error() string {
@@ -258,11 +314,33 @@ func CheckResponse(r *http.Response, errorBody interface{}) error {
 			errorResponse.Message = string(data)
 		}
 		errorResponse.TransferError = errorBody
+		errorResponse.details = decodeTransferError(data, errorBody)
+		if errorBody == nil && errorResponse.details != nil {
+			errorResponse.TransferError = errorResponse.details
+		}
 	}
 
 	return errorResponse
 }
 
+// apiPath formats an API path, escaping each of ids into a single path segment. Empty and dot segments, which would
+// reach another resource, fail with ErrValidation.
+func apiPath(format string, ids ...string) (string, error) {
+	var errs validationErrors
+	escaped := make([]interface{}, len(ids))
+	for i, id := range ids {
+		switch strings.TrimSpace(id) {
+		case "", ".", "..":
+			errs.add(fmt.Sprintf("invalid path parameter %q", id), "id")
+		}
+		escaped[i] = url.PathEscape(id)
+	}
+	if err := errs.err(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(format, escaped...), nil
+}
+
 // NewAPIRequest creates an API request. A relative URL PATH can be provided in pathStr, which will be resolved to the
 // ApiBaseURL of the Client.
 func (c *Client) NewAPIRequest(method, pathStr string, body interface{}) (*http.Request, error) {
@@ -298,13 +376,51 @@ func (c *Client) AddIdempotencyHeader(req *http.Request, idempotencyKey string)
 		if len(trimmedIdempotencyKey) > idempotencyKeyMaxSize {
 			return errors.New("invalid idempotency key")
 		}
-		req.Header.Add("x-stone-idempotency-key", trimmedIdempotencyKey)
+		req.Header.Add(idempotencyHeader, trimmedIdempotencyKey)
 	}
 
 	return nil
 }
 
+// postIdempotent posts input to path with an idempotency key, generating it into *idempotencyKey when empty, and
+// decodes the response into out.
+func (c *Client) postIdempotent(ctx context.Context, path string, input interface{}, idempotencyKey *string, out interface{}) (*Response, error) {
+	req, err := c.NewAPIRequest(http.MethodPost, path, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if *idempotencyKey == "" {
+		*idempotencyKey = uuid.NewString()
+	}
+	if err := c.AddIdempotencyHeader(req, *idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	return c.Do(req.WithContext(ctx), out, nil)
+}
+
+// Do sends an API request and decodes the response into successResponse or errorResponse. Requests to ApiBaseURL are
+// authenticated automatically when the client has credentials and the request carries no Authorization header.
 func (c *Client) Do(req *http.Request, successResponse, errorResponse interface{}) (*Response, error) {
+	return c.do(req, successResponse, errorResponse, true)
+}
+
+func (c *Client) do(req *http.Request, successResponse, errorResponse interface{}, authenticate bool) (*Response, error) {
+	var accessToken string
+	if authenticate && c.needsAuthentication(req) {
+		token, err := c.accessToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		accessToken = token.AccessToken
+		req = req.Clone(req.Context())
+		if req.Header == nil {
+			req.Header = make(http.Header)
+		}
+		req.Header.Set("Authorization", token.Type()+" "+accessToken)
+	}
+
 	_, span := c.newSpan(
 		req.Context(),
 		"merchant openbank client request",
@@ -316,12 +432,7 @@ func (c *Client) Do(req *http.Request, successResponse, errorResponse interface{
 	c.addSpanAttribute(span, attribute.String("http.request.protocol", req.Proto))
 	c.addSpanAttribute(span, attribute.String("http.request.method", req.Method))
 
-	if c.debug {
-		d, _ := httputil.DumpRequestOut(req, true)
-		c.log.Infof(">>> REQUEST:\n%s", string(d))
-	}
-
-	resp, err := c.client.Do(req)
+	resp, err := c.send(req, span)
 	if err != nil {
 		c.setSpanStatus(span, codes.Error, "error executing request")
 		c.spanRecordError(span, err)
@@ -330,6 +441,10 @@ func (c *Client) Do(req *http.Request, successResponse, errorResponse interface{
 
 	c.addSpanAttribute(span, attribute.Int("http.response.status_code", resp.StatusCode))
 
+	if accessToken != "" && resp.StatusCode == http.StatusUnauthorized {
+		c.invalidateToken(accessToken)
+	}
+
 	defer func() {
 		if rerr := resp.Body.Close(); err == nil {
 			err = rerr
@@ -363,6 +478,76 @@ func (c *Client) Do(req *http.Request, successResponse, errorResponse interface{
 	return response, err
 }
 
+func (c *Client) needsAuthentication(req *http.Request) bool {
+	return c.canAuthenticate() &&
+		req.Header.Get("Authorization") == "" &&
+		req.URL != nil && req.URL.Host == c.ApiBaseURL.Host
+}
+
+// send executes the request, retrying it according to the client RetryPolicy. Every attempt is recorded as an event
+// on the given span.
+func (c *Client) send(req *http.Request, span trace.Span) (*http.Response, error) {
+	policy := c.retryPolicy
+	retry := policy.enabled() && isRetryableMethod(req)
+	if retry {
+		if err := rewindableBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.debug {
+			d, _ := httputil.DumpRequestOut(req, true)
+			c.log.Infof(">>> REQUEST:\n%s", string(d))
+		}
+
+		resp, err := c.client.Do(req)
+
+		attrs := []attribute.KeyValue{attribute.Int("http.request.resend_count", attempt-1)}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error.message", err.Error()))
+		} else {
+			attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		c.addSpanEvent(span, "http.request.attempt", attrs...)
+
+		if !retry || attempt >= policy.MaxAttempts {
+			return resp, err
+		}
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if isContextError(err) {
+				return resp, err
+			}
+			wait = policy.backoff(attempt)
+		case isRetryableStatus(resp.StatusCode):
+			wait = policy.backoff(attempt)
+			if d, ok := retryAfter(resp, time.Now()); ok {
+				if policy.MaxRetryAfter > 0 && d > policy.MaxRetryAfter {
+					return resp, nil
+				}
+				wait = d
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := sleepContext(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (c *Client) newSpan(ctx context.Context, name string, kind trace.SpanKind) (context.Context, trace.Span) {
 	if c.otelTracer != nil {
 		return c.otelTracer.Start(ctx, name, trace.WithSpanKind(kind))
@@ -389,6 +574,12 @@ func (c *Client) endSpan(span trace.Span) {
 	}
 }
 
+func (c *Client) addSpanEvent(span trace.Span, name string, attributes ...attribute.KeyValue) {
+	if span != nil {
+		span.AddEvent(name, trace.WithAttributes(attributes...))
+	}
+}
+
 func (c *Client) addSpanAttribute(span trace.Span, attributes ...attribute.KeyValue) {
 	if span != nil {
 		span.SetAttributes(attributes...)
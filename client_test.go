@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
@@ -231,3 +232,18 @@ func TestDoMethod(t *testing.T) {
 		})
 	}
 }
+
+// newTestAPI starts a test server with handler and returns a client whose ApiBaseURL points to it.
+func newTestAPI(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	baseURL, _ := SetBaseURL(server.URL)
+	c, err := NewClient(baseURL)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	return c
+}
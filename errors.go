@@ -0,0 +1,101 @@
+package openbank
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *ErrorResponse through errors.Is, based on the HTTP status code of the response.
+var (
+	ErrUnauthorized = errors.New("openbank: unauthorized")
+	ErrForbidden    = errors.New("openbank: forbidden")
+	ErrNotFound     = errors.New("openbank: not found")
+	ErrConflict     = errors.New("openbank: conflict")
+	ErrValidation   = errors.New("openbank: validation failed")
+	ErrRateLimited  = errors.New("openbank: rate limited")
+	ErrServerError  = errors.New("openbank: server error")
+)
+
+// Is reports whether the response status code maps to target, allowing callers to use errors.Is with the sentinel
+// errors of this package. ErrConflict also covers idempotency key replays, which Stone answers with 409.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+
+	code := r.Response.StatusCode
+	switch target {
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrConflict:
+		return code == http.StatusConflict
+	case ErrValidation:
+		return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrServerError:
+		return code >= 500
+	}
+	return false
+}
+
+// Unwrap exposes the decoded *TransferError, so it can be retrieved with errors.As.
+func (r *ErrorResponse) Unwrap() error {
+	if r.details == nil {
+		return nil
+	}
+	return r.details
+}
+
+// Details returns the error body decoded as TransferError, when the body matches that shape.
+func (r *ErrorResponse) Details() (*TransferError, bool) {
+	return r.details, r.details != nil
+}
+
+func (e *TransferError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Type)
+	for _, details := range [][]ErrorDetail{e.ValidationErrors, e.Reason} {
+		for _, d := range details {
+			if b.Len() > 0 {
+				b.WriteString("; ")
+			}
+			b.WriteString(d.String())
+		}
+	}
+	return b.String()
+}
+
+func (d ErrorDetail) String() string {
+	if len(d.Path) == 0 {
+		return d.Error
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(d.Path, "."), d.Error)
+}
+
+func (e *TransferError) empty() bool {
+	return e.Type == "" && len(e.ValidationErrors) == 0 && len(e.Reason) == 0
+}
+
+// decodeTransferError decodes data as TransferError, reusing errorBody when the caller already asked for one.
+func decodeTransferError(data []byte, errorBody interface{}) *TransferError {
+	if te, ok := errorBody.(*TransferError); ok && te != nil {
+		if te.empty() {
+			return nil
+		}
+		return te
+	}
+
+	var te TransferError
+	if err := json.Unmarshal(data, &te); err != nil || te.empty() {
+		return nil
+	}
+	return &te
+}
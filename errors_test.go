@@ -0,0 +1,82 @@
+package openbank
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestErrorResponseIs(t *testing.T) {
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrValidation, ErrRateLimited, ErrServerError}
+	testCases := []struct {
+		Name       string
+		StatusCode int
+		Expected   error
+	}{
+		{Name: "Should map 401 to ErrUnauthorized", StatusCode: 401, Expected: ErrUnauthorized},
+		{Name: "Should map 403 to ErrForbidden", StatusCode: 403, Expected: ErrForbidden},
+		{Name: "Should map 404 to ErrNotFound", StatusCode: 404, Expected: ErrNotFound},
+		{Name: "Should map 409 to ErrConflict", StatusCode: 409, Expected: ErrConflict},
+		{Name: "Should map 400 to ErrValidation", StatusCode: 400, Expected: ErrValidation},
+		{Name: "Should map 422 to ErrValidation", StatusCode: 422, Expected: ErrValidation},
+		{Name: "Should map 429 to ErrRateLimited", StatusCode: 429, Expected: ErrRateLimited},
+		{Name: "Should map 503 to ErrServerError", StatusCode: 503, Expected: ErrServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := CheckResponse(newTestResponse(testCase.StatusCode, `{}`), nil)
+
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == testCase.Expected) {
+					t.Errorf("errors.Is(%d, %v) = %v", testCase.StatusCode, sentinel, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckResponseDecodesTransferError(t *testing.T) {
+	body := `{"type":"srn:error:validation","validation_errors":[{"error":"required","path":["target","account"]}]}`
+
+	err := CheckResponse(newTestResponse(422, body), nil)
+
+	var te *TransferError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TransferError in %v", err)
+	}
+	if te.Type != "srn:error:validation" || len(te.ValidationErrors) != 1 {
+		t.Errorf("unexpected transfer error %+v", te)
+	}
+	if got := te.Error(); got != "srn:error:validation; target.account: required" {
+		t.Errorf("TransferError.Error() = %q", got)
+	}
+}
+
+func TestCheckResponseIgnoresOtherBodies(t *testing.T) {
+	err := CheckResponse(newTestResponse(400, `{"data":[{"field":"Test"}]}`), new(responseBody))
+
+	var te *TransferError
+	if errors.As(err, &te) {
+		t.Errorf("expected no *TransferError, got %+v", te)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
+
+func newTestResponse(statusCode int, body string) *http.Response {
+	reqURL, _ := url.Parse("http://127.0.0.1:3001/test")
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    reqURL,
+		},
+	}
+}
@@ -0,0 +1,318 @@
+package openbank
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventState is the delivery state of a webhook event in an EventStore.
+type EventState int
+
+const (
+	// EventNew means the event was never seen. Reserve moves it to EventInProgress.
+	EventNew EventState = iota
+	// EventInProgress means another delivery of the event is being handled.
+	EventInProgress
+	// EventProcessed means the event was already handled successfully.
+	EventProcessed
+)
+
+// DefaultEventReservationTTL is how long the provided stores keep an event in progress before a new delivery may
+// reserve it again, in case the process handling it crashed.
+const DefaultEventReservationTTL = 10 * time.Minute
+
+// EventStore records which webhook events were handled, so that redeliveries are not processed twice.
+//
+// Implementations must be safe for concurrent use. Reserve must be atomic: when several deliveries of the same event
+// race, only one of them may observe EventNew. Reservations should expire, so that an event reserved by a process that
+// crashed is eventually handled again.
+type EventStore interface {
+	// Reserve returns the state of the event before the call, marking new events as in progress.
+	Reserve(ctx context.Context, id string) (EventState, error)
+	// Complete marks a reserved event as processed.
+	Complete(ctx context.Context, id string) error
+	// Release drops a reservation after a failed delivery, so that the event can be handled again.
+	Release(ctx context.Context, id string) error
+}
+
+// WithWebhookEventStore makes WebhookHandler deduplicate events by ID through store.
+func WithWebhookEventStore(store EventStore) ClientOpt {
+	return func(c *Client) {
+		c.webhookEvents = store
+	}
+}
+
+// WithWebhookTimestampWindow makes ParseWebhook reject events whose signed iat claim is further than window from
+// the current time, in either direction.
+func WithWebhookTimestampWindow(window time.Duration) ClientOpt {
+	return func(c *Client) {
+		c.webhookWindow = window
+	}
+}
+
+// MemoryEventStore is an EventStore that keeps the IDs of the most recent events in memory, evicting the least
+// recently used processed ones beyond its capacity. Events in progress are never evicted before their reservation
+// expires.
+type MemoryEventStore struct {
+	// ReservationTTL is how long an event stays in progress. Zero means DefaultEventReservationTTL. It must be set
+	// before the store is used.
+	ReservationTTL time.Duration
+
+	m        sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type memoryEvent struct {
+	id       string
+	state    EventState
+	reserved time.Time
+}
+
+// NewMemoryEventStore creates a MemoryEventStore holding up to capacity events.
+func NewMemoryEventStore(capacity int) *MemoryEventStore {
+	return &MemoryEventStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryEventStore) Reserve(_ context.Context, id string) (EventState, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := time.Now()
+	if el, ok := s.entries[id]; ok {
+		s.order.MoveToFront(el)
+		event := el.Value.(*memoryEvent)
+		if !s.expired(event, now) {
+			return event.state, nil
+		}
+		event.reserved = now
+		return EventNew, nil
+	}
+
+	s.entries[id] = s.order.PushFront(&memoryEvent{id: id, state: EventInProgress, reserved: now})
+	s.trim(now)
+	return EventNew, nil
+}
+
+func (s *MemoryEventStore) Complete(_ context.Context, id string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if el, ok := s.entries[id]; ok {
+		el.Value.(*memoryEvent).state = EventProcessed
+		return nil
+	}
+	s.entries[id] = s.order.PushFront(&memoryEvent{id: id, state: EventProcessed})
+	s.trim(time.Now())
+	return nil
+}
+
+// expired reports whether event is in progress past its reservation.
+func (s *MemoryEventStore) expired(event *memoryEvent, now time.Time) bool {
+	return event.state == EventInProgress && now.Sub(event.reserved) > reservationTTL(s.ReservationTTL)
+}
+
+// trim evicts the least recently used events beyond the capacity, skipping the ones in progress.
+func (s *MemoryEventStore) trim(now time.Time) {
+	for el := s.order.Back(); el != nil && s.capacity > 0 && s.order.Len() > s.capacity; {
+		prev := el.Prev()
+		if event := el.Value.(*memoryEvent); event.state != EventInProgress || s.expired(event, now) {
+			s.order.Remove(el)
+			delete(s.entries, event.id)
+		}
+		el = prev
+	}
+}
+
+func (s *MemoryEventStore) Release(_ context.Context, id string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if el, ok := s.entries[id]; ok && el.Value.(*memoryEvent).state == EventInProgress {
+		s.order.Remove(el)
+		delete(s.entries, id)
+	}
+	return nil
+}
+
+// FileEventStore is an EventStore that persists processed event IDs in an append-only file, so deduplication
+// survives restarts. In-progress reservations are kept in memory, so the file must not be shared between processes.
+//
+// Entries older than the retention given to NewFileEventStore are forgotten, and dropped from the file when it is
+// opened and then about once per retention period.
+type FileEventStore struct {
+	// ReservationTTL is how long an event stays in progress. Zero means DefaultEventReservationTTL. It must be set
+	// before the store is used.
+	ReservationTTL time.Duration
+
+	m          sync.Mutex
+	path       string
+	retention  time.Duration
+	compacted  time.Time
+	f          *os.File
+	processed  map[string]time.Time
+	inProgress map[string]time.Time
+}
+
+// NewFileEventStore opens, or creates, the event log at path, compacting entries older than retention. A zero
+// retention keeps every entry.
+func NewFileEventStore(path string, retention time.Duration) (*FileEventStore, error) {
+	processed, err := readEventLog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileEventStore{
+		path:       path,
+		retention:  retention,
+		processed:  processed,
+		inProgress: make(map[string]time.Time),
+	}
+	if err := s.compact(time.Now()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func readEventLog(path string) (map[string]time.Time, error) {
+	processed := make(map[string]time.Time)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return processed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ts, id, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || id == "" {
+			continue
+		}
+		secs, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, dup := processed[id]; dup {
+			continue
+		}
+		processed[id] = time.Unix(secs, 0)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading event log %s: %w", path, err)
+	}
+	return processed, nil
+}
+
+// expired reports whether an event processed at completed is past the retention.
+func (s *FileEventStore) expired(completed, now time.Time) bool {
+	return s.retention > 0 && now.Sub(completed) > s.retention
+}
+
+// compact drops the expired entries and rewrites the log with the retained ones, reopening it for appends.
+func (s *FileEventStore) compact(now time.Time) error {
+	var b strings.Builder
+	for id, completed := range s.processed {
+		if s.expired(completed, now) {
+			delete(s.processed, id)
+			continue
+		}
+		fmt.Fprintf(&b, "%d %s\n", completed.Unix(), id)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if s.f != nil {
+		s.f.Close()
+	}
+	s.f, s.compacted = f, now
+	return nil
+}
+
+func (s *FileEventStore) Reserve(_ context.Context, id string) (EventState, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := time.Now()
+	if completed, ok := s.processed[id]; ok && !s.expired(completed, now) {
+		return EventProcessed, nil
+	}
+	if reserved, ok := s.inProgress[id]; ok && now.Sub(reserved) <= reservationTTL(s.ReservationTTL) {
+		return EventInProgress, nil
+	}
+	s.inProgress[id] = now
+	return EventNew, nil
+}
+
+func (s *FileEventStore) Complete(_ context.Context, id string) error {
+	if strings.ContainsAny(id, " \n") {
+		return fmt.Errorf("invalid event id %q", id)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := time.Now()
+	if _, err := fmt.Fprintf(s.f, "%d %s\n", now.Unix(), id); err != nil {
+		return err
+	}
+	if err := s.f.Sync(); err != nil {
+		return err
+	}
+	delete(s.inProgress, id)
+	s.processed[id] = now
+
+	if s.retention > 0 && now.Sub(s.compacted) >= s.retention {
+		if err := s.compact(now); err != nil {
+			return fmt.Errorf("compacting event log %s: %w", s.path, err)
+		}
+	}
+	return nil
+}
+
+func (s *FileEventStore) Release(_ context.Context, id string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	delete(s.inProgress, id)
+	return nil
+}
+
+// Close closes the underlying file.
+func (s *FileEventStore) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.f.Close()
+}
+
+func reservationTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return DefaultEventReservationTTL
+	}
+	return ttl
+}
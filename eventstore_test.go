@@ -0,0 +1,237 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestEventStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	fileStore, err := NewFileEventStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer fileStore.Close()
+
+	testCases := []struct {
+		Name  string
+		Store EventStore
+	}{
+		{Name: "MemoryEventStore", Store: NewMemoryEventStore(10)},
+		{Name: "FileEventStore", Store: fileStore},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+			expect := func(id string, expected EventState) {
+				t.Helper()
+				if state, err := testCase.Store.Reserve(ctx, id); err != nil || state != expected {
+					t.Errorf("Reserve(%s) = %v, %v, expected %v", id, state, err, expected)
+				}
+			}
+
+			expect("evt-1", EventNew)
+			expect("evt-1", EventInProgress)
+
+			_ = testCase.Store.Release(ctx, "evt-1")
+			expect("evt-1", EventNew)
+
+			_ = testCase.Store.Complete(ctx, "evt-1")
+			expect("evt-1", EventProcessed)
+		})
+	}
+
+	// Processed events survive reopening the file.
+	fileStore.Close()
+	reopened, err := NewFileEventStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer reopened.Close()
+	if state, _ := reopened.Reserve(context.Background(), "evt-1"); state != EventProcessed {
+		t.Errorf("Reserve(evt-1) after reopen = %v, expected EventProcessed", state)
+	}
+}
+
+func TestFileEventStoreRetention(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.log")
+	s, err := NewFileEventStore(path, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer s.Close()
+
+	_ = s.Complete(ctx, "evt-1")
+	time.Sleep(60 * time.Millisecond)
+	if err := s.Complete(ctx, "evt-2"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	if state, _ := s.Reserve(ctx, "evt-1"); state != EventNew {
+		t.Errorf("Reserve(evt-1) past the retention = %v, expected EventNew", state)
+	}
+	if state, _ := s.Reserve(ctx, "evt-2"); state != EventProcessed {
+		t.Errorf("Reserve(evt-2) = %v, expected EventProcessed", state)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.Contains(data, []byte("evt-1")) || !bytes.Contains(data, []byte("evt-2")) {
+		t.Errorf("event log %q, %v, expected only evt-2", data, err)
+	}
+}
+
+func TestMemoryEventStoreEvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryEventStore(2)
+
+	for _, id := range []string{"a", "b", "c"} {
+		_, _ = s.Reserve(ctx, id)
+		_ = s.Complete(ctx, id)
+	}
+
+	if state, _ := s.Reserve(ctx, "a"); state != EventNew {
+		t.Errorf("expected evicted event to be new, got %v", state)
+	}
+	if state, _ := s.Reserve(ctx, "c"); state != EventProcessed {
+		t.Errorf("expected recent event to be processed, got %v", state)
+	}
+}
+
+func TestMemoryEventStoreKeepsInProgress(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryEventStore(2)
+
+	_, _ = s.Reserve(ctx, "a")
+	for _, id := range []string{"b", "c", "d"} {
+		_ = s.Complete(ctx, id)
+	}
+
+	if state, _ := s.Reserve(ctx, "a"); state != EventInProgress {
+		t.Errorf("expected event in progress to survive eviction, got %v", state)
+	}
+	if state, _ := s.Reserve(ctx, "b"); state != EventNew {
+		t.Errorf("expected Complete to evict the oldest processed event, got %v", state)
+	}
+}
+
+func TestEventStoresReservationTTL(t *testing.T) {
+	fileStore, err := NewFileEventStore(filepath.Join(t.TempDir(), "events.log"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer fileStore.Close()
+	fileStore.ReservationTTL = 10 * time.Millisecond
+	memoryStore := NewMemoryEventStore(10)
+	memoryStore.ReservationTTL = 10 * time.Millisecond
+
+	for _, store := range []EventStore{memoryStore, fileStore} {
+		ctx := context.Background()
+		_, _ = store.Reserve(ctx, "evt-1")
+		if state, _ := store.Reserve(ctx, "evt-1"); state != EventInProgress {
+			t.Errorf("%T: Reserve() = %v, expected EventInProgress", store, state)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		if state, _ := store.Reserve(ctx, "evt-1"); state != EventNew {
+			t.Errorf("%T: Reserve() after the TTL = %v, expected EventNew", store, state)
+		}
+	}
+}
+
+func TestWebhookHandlerRecoversPanic(t *testing.T) {
+	f := newWebhookFixture(t)
+	f.client.ApplyOpts(WithWebhookEventStore(NewMemoryEventStore(100)))
+
+	panicNext := true
+	handler := f.client.WebhookHandler(func(ctx context.Context, event *types.WebhookEvent) error {
+		if panicNext {
+			panicNext = false
+			panic("boom")
+		}
+		return nil
+	})
+
+	body := f.body(t, testWebhookPayload("evt-1", time.Now().Add(time.Hour)), f.stoneKey, f.kid)
+	for _, expected := range []int{http.StatusInternalServerError, http.StatusOK} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+		if rec.Code != expected {
+			t.Errorf("delivery status = %d, expected %d", rec.Code, expected)
+		}
+	}
+}
+
+func TestWebhookHandlerDeduplicates(t *testing.T) {
+	f := newWebhookFixture(t)
+	f.client.ApplyOpts(WithWebhookEventStore(NewMemoryEventStore(100)))
+
+	calls := 0
+	failNext := true
+	handler := f.client.WebhookHandler(func(ctx context.Context, event *types.WebhookEvent) error {
+		calls++
+		if failNext {
+			failNext = false
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	body := f.body(t, testWebhookPayload("evt-1", time.Now().Add(time.Hour)), f.stoneKey, f.kid)
+	deliver := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+		return rec.Code
+	}
+
+	if code := deliver(); code != http.StatusInternalServerError {
+		t.Errorf("first delivery status = %d, expected 500", code)
+	}
+	if code := deliver(); code != http.StatusOK {
+		t.Errorf("second delivery status = %d, expected 200", code)
+	}
+	if code := deliver(); code != http.StatusOK {
+		t.Errorf("duplicate delivery status = %d, expected 200", code)
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, expected 2", calls)
+	}
+}
+
+func TestParseWebhookTimestampWindow(t *testing.T) {
+	f := newWebhookFixture(t)
+	f.client.ApplyOpts(WithWebhookTimestampWindow(5 * time.Minute))
+
+	testCases := []struct {
+		Name          string
+		IssuedAt      time.Time
+		ExpectedError error
+	}{
+		{Name: "Should accept recent event", IssuedAt: time.Now().Add(-time.Minute)},
+		{Name: "Should reject old event", IssuedAt: time.Now().Add(-10 * time.Minute), ExpectedError: ErrWebhookTimestamp},
+		{Name: "Should reject event from the future", IssuedAt: time.Now().Add(10 * time.Minute), ExpectedError: ErrWebhookTimestamp},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			payload := testWebhookPayload("evt-1", time.Now().Add(time.Hour))
+			payload["iat"] = testCase.IssuedAt.Unix()
+
+			_, err := f.client.ParseWebhook(f.body(t, payload, f.stoneKey, f.kid))
+
+			if !errors.Is(err, testCase.ExpectedError) {
+				t.Errorf("expected %v, got %v", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
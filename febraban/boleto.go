@@ -0,0 +1,90 @@
+package febraban
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// CurrencyBRL is the currency code of boletos in reais.
+const CurrencyBRL = 9
+
+// The due date factor counts days since the base date. It reached 9999 on 2025-02-21 and restarted at 1000 on the
+// next day, so every factor from 1000 on repeats every factorCycle days.
+var factorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+const factorCycle = 9000
+
+// Boleto holds the fields of a bank boleto barcode.
+type Boleto struct {
+	// BankCode is the 3 digit COMPE code of the issuing bank.
+	BankCode string
+	// Currency is CurrencyBRL for boletos in reais.
+	Currency int
+	// DueFactor encodes the due date, see DueFactor. Zero means the boleto has no due date.
+	DueFactor int
+	Amount    types.Money
+	// FreeField is the 25 digit field defined by the issuing bank.
+	FreeField string
+}
+
+// Code builds the barcode of the boleto, computing its check digit.
+func (b Boleto) Code() (Code, error) {
+	factor, ok := pad(int64(b.DueFactor), 4)
+	if !ok {
+		return Code{}, fmt.Errorf("%w: due factor %d out of range", ErrMalformed, b.DueFactor)
+	}
+	amount, ok := pad(b.Amount.Cents(), 10)
+	if !ok {
+		return Code{}, fmt.Errorf("%w: amount %s out of range", ErrMalformed, b.Amount)
+	}
+	switch {
+	case len(b.BankCode) != 3 || !isDigits(b.BankCode):
+		return Code{}, fmt.Errorf("%w: bank code %q must have 3 digits", ErrMalformed, b.BankCode)
+	case b.BankCode[0] == '8':
+		return Code{}, fmt.Errorf("%w: bank code %q would be read as a convenio", ErrMalformed, b.BankCode)
+	case b.Currency < 0 || b.Currency > 9:
+		return Code{}, fmt.Errorf("%w: currency %d must be a single digit", ErrMalformed, b.Currency)
+	case len(b.FreeField) != 25 || !isDigits(b.FreeField):
+		return Code{}, fmt.Errorf("%w: free field must have 25 digits", ErrMalformed)
+	}
+
+	digits := b.BankCode + strconv.Itoa(b.Currency) + factor + amount + b.FreeField
+	barcode := digits[:4] + strconv.Itoa(boletoDigit(digits)) + digits[4:]
+	return Code{barcode: barcode}, nil
+}
+
+// DueDate returns the due date of the boleto, or false when it has none. Since factors repeat every 9000 days, the
+// date of the cycle nearest to ref, usually the current date, is chosen.
+func (b Boleto) DueDate(ref time.Time) (types.Date, bool) {
+	if b.DueFactor <= 0 || b.DueFactor > 9999 {
+		return types.Date{}, false
+	}
+
+	days := b.DueFactor
+	if days >= 1000 {
+		refDays := int(time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC).Sub(factorBase).Hours() / 24)
+		cycle := (refDays - days + factorCycle/2) / factorCycle
+		if refDays-days+factorCycle/2 < 0 {
+			cycle = 0
+		}
+		days += cycle * factorCycle
+	}
+
+	d := factorBase.AddDate(0, 0, days)
+	return types.NewDate(d.Year(), d.Month(), d.Day()), true
+}
+
+// DueFactor returns the due date factor of date, or false for dates not after the base date of 1997-10-07.
+func DueFactor(date types.Date) (int, bool) {
+	days := int(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Sub(factorBase).Hours() / 24)
+	if days <= 0 {
+		return 0, false
+	}
+	if days < 1000 {
+		return days, true
+	}
+	return (days-1000)%factorCycle + 1000, true
+}
@@ -0,0 +1,68 @@
+package febraban
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// Convenio segments, the second digit of the barcode.
+const (
+	SegmentCityHall     = 1
+	SegmentSanitation   = 2
+	SegmentEnergy       = 3
+	SegmentTelecom      = 4
+	SegmentGovernment   = 5
+	SegmentCNPJ         = 6
+	SegmentTrafficFines = 7
+	SegmentBank         = 9
+)
+
+// Convenio value identifiers, the third digit of the barcode. They select whether the amount is in reais or is a
+// reference quantity, and the check digit algorithm.
+const (
+	ValueReaisMod10     = 6
+	ValueReferenceMod10 = 7
+	ValueReaisMod11     = 8
+	ValueReferenceMod11 = 9
+)
+
+// Convenio holds the fields of a convenio barcode.
+type Convenio struct {
+	Segment int
+	ValueID int
+	// Amount is in reais for ValueReaisMod10 and ValueReaisMod11, otherwise it is a reference quantity.
+	Amount types.Money
+	// Company identifies the collecting company: the 8 digit root of its CNPJ for SegmentCNPJ and a 4 digit code
+	// assigned by FEBRABAN otherwise.
+	Company string
+	// FreeField is defined by the company, with 25 digits, or 21 for SegmentCNPJ.
+	FreeField string
+}
+
+// Code builds the barcode of the convenio, computing its check digit.
+func (c Convenio) Code() (Code, error) {
+	amount, ok := pad(c.Amount.Cents(), 11)
+	if !ok {
+		return Code{}, fmt.Errorf("%w: amount %s out of range", ErrMalformed, c.Amount)
+	}
+	company := 4
+	if c.Segment == SegmentCNPJ {
+		company = 8
+	}
+	switch {
+	case c.Segment < 1 || c.Segment > 9:
+		return Code{}, fmt.Errorf("%w: segment %d must be between 1 and 9", ErrMalformed, c.Segment)
+	case c.ValueID < ValueReaisMod10 || c.ValueID > ValueReferenceMod11:
+		return Code{}, fmt.Errorf("%w: value identifier %d must be between 6 and 9", ErrMalformed, c.ValueID)
+	case len(c.Company) != company || !isDigits(c.Company):
+		return Code{}, fmt.Errorf("%w: company must have %d digits", ErrMalformed, company)
+	case len(c.FreeField) != 29-company || !isDigits(c.FreeField):
+		return Code{}, fmt.Errorf("%w: free field must have %d digits", ErrMalformed, 29-company)
+	}
+
+	digits := "8" + strconv.Itoa(c.Segment) + strconv.Itoa(c.ValueID) + amount + c.Company + c.FreeField
+	barcode := digits[:3] + strconv.Itoa(convenioDigit(digits[2], digits)) + digits[3:]
+	return Code{barcode: barcode}, nil
+}
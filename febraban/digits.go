@@ -0,0 +1,47 @@
+package febraban
+
+// mod10 computes the FEBRABAN modulo 10 check digit: weights 2 and 1 alternate from the rightmost digit and the
+// digits of each product are summed.
+func mod10(digits string) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		p := digit(digits[i]) * (2 - (len(digits)-1-i)%2)
+		sum += p/10 + p%10
+	}
+	return (10 - sum%10) % 10
+}
+
+// mod11 returns the modulo 11 remainder of digits, with weights from 2 to 9 repeating from the rightmost digit.
+func mod11(digits string) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += digit(digits[i]) * (2 + (len(digits)-1-i)%8)
+	}
+	return sum % 11
+}
+
+// boletoDigit computes the general check digit of a boleto barcode, where 0, 10 and 11 become 1.
+func boletoDigit(digits string) int {
+	dv := 11 - mod11(digits)
+	if dv == 0 || dv >= 10 {
+		return 1
+	}
+	return dv
+}
+
+// convenioDigit computes a convenio check digit. The value identifier, the third digit of the barcode, selects
+// modulo 10 (6 and 7) or modulo 11 (8 and 9).
+func convenioDigit(valueID byte, digits string) int {
+	if valueID == '6' || valueID == '7' {
+		return mod10(digits)
+	}
+	r := mod11(digits)
+	if r <= 1 {
+		return 0
+	}
+	return 11 - r
+}
+
+func validValueID(valueID byte) bool {
+	return valueID >= '6' && valueID <= '9'
+}
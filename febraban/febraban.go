@@ -0,0 +1,221 @@
+// Package febraban parses, validates and builds the barcodes and digitable lines ("linhas digitáveis") defined by
+// FEBRABAN for bank boletos and for convenios, the utility bills and taxes collected by banks. It works offline.
+package febraban
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+var (
+	// ErrMalformed is returned when a code has unexpected characters or length, or fields out of range.
+	ErrMalformed = errors.New("febraban: malformed code")
+	// ErrCheckDigit is returned when a check digit of a barcode or digitable line does not match.
+	ErrCheckDigit = errors.New("febraban: check digit mismatch")
+)
+
+// Kind tells boletos from convenios.
+type Kind int
+
+const (
+	// KindBoleto is a bank boleto, whose barcode starts with the bank code.
+	KindBoleto Kind = iota + 1
+	// KindConvenio is a utility bill or tax slip, whose barcode starts with 8.
+	KindConvenio
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBoleto:
+		return "boleto"
+	case KindConvenio:
+		return "convenio"
+	}
+	return "unknown"
+}
+
+// Code is a valid 44 digit barcode. The zero value is not valid; create it with Parse or with the Code methods of
+// Boleto and Convenio.
+type Code struct {
+	barcode string
+}
+
+// Parse parses a barcode, or the 47 digit digitable line of a boleto or the 48 digit one of a convenio, checking every
+// check digit. Spaces, dots and hyphens are ignored.
+func Parse(code string) (Code, error) {
+	digits := strings.NewReplacer(" ", "", ".", "", "-", "").Replace(strings.TrimSpace(code))
+	if !isDigits(digits) {
+		return Code{}, fmt.Errorf("%w: %q has unexpected characters", ErrMalformed, code)
+	}
+
+	switch len(digits) {
+	case 44:
+		return ParseBarcode(digits)
+	case 47, 48:
+		return ParseDigitableLine(digits)
+	}
+	return Code{}, fmt.Errorf("%w: expected 44, 47 or 48 digits, got %d", ErrMalformed, len(digits))
+}
+
+// ParseBarcode parses the 44 digits of a barcode, checking its general check digit.
+func ParseBarcode(barcode string) (Code, error) {
+	if len(barcode) != 44 || !isDigits(barcode) {
+		return Code{}, fmt.Errorf("%w: a barcode has 44 digits", ErrMalformed)
+	}
+
+	if barcode[0] == '8' {
+		if !validValueID(barcode[2]) {
+			return Code{}, fmt.Errorf("%w: unknown convenio value identifier %c", ErrMalformed, barcode[2])
+		}
+		if convenioDigit(barcode[2], barcode[:3]+barcode[4:]) != digit(barcode[3]) {
+			return Code{}, fmt.Errorf("%w: general check digit", ErrCheckDigit)
+		}
+	} else if boletoDigit(barcode[:4]+barcode[5:]) != digit(barcode[4]) {
+		return Code{}, fmt.Errorf("%w: general check digit", ErrCheckDigit)
+	}
+
+	return Code{barcode: barcode}, nil
+}
+
+// ParseDigitableLine parses the digits of a digitable line, 47 for boletos and 48 for convenios, checking the check
+// digit of every field and the general one.
+func ParseDigitableLine(line string) (Code, error) {
+	if !isDigits(line) {
+		return Code{}, fmt.Errorf("%w: a digitable line has only digits", ErrMalformed)
+	}
+
+	var barcode string
+	switch len(line) {
+	case 47:
+		// Three fields with a modulo 10 check digit each, then the general check digit, due date factor and amount.
+		for i, f := range [][2]int{{0, 9}, {10, 20}, {21, 31}} {
+			if mod10(line[f[0]:f[1]]) != digit(line[f[1]]) {
+				return Code{}, fmt.Errorf("%w: field %d of digitable line", ErrCheckDigit, i+1)
+			}
+		}
+		barcode = line[0:4] + line[32:47] + line[4:9] + line[10:20] + line[21:31]
+		if barcode[0] == '8' {
+			return Code{}, fmt.Errorf("%w: convenio digitable lines have 48 digits", ErrMalformed)
+		}
+	case 48:
+		if line[0] != '8' {
+			return Code{}, fmt.Errorf("%w: boleto digitable lines have 47 digits", ErrMalformed)
+		}
+		if !validValueID(line[2]) {
+			return Code{}, fmt.Errorf("%w: unknown convenio value identifier %c", ErrMalformed, line[2])
+		}
+		// Four blocks of 11 digits, each followed by its check digit.
+		for i := 0; i < 4; i++ {
+			block := line[i*12 : i*12+11]
+			if convenioDigit(line[2], block) != digit(line[i*12+11]) {
+				return Code{}, fmt.Errorf("%w: block %d of digitable line", ErrCheckDigit, i+1)
+			}
+			barcode += block
+		}
+	default:
+		return Code{}, fmt.Errorf("%w: a digitable line has 47 or 48 digits, got %d", ErrMalformed, len(line))
+	}
+
+	return ParseBarcode(barcode)
+}
+
+func (c Code) Kind() Kind {
+	switch {
+	case c.barcode == "":
+		return 0
+	case c.barcode[0] == '8':
+		return KindConvenio
+	}
+	return KindBoleto
+}
+
+// Barcode returns the 44 digits encoded in the bars.
+func (c Code) Barcode() types.Barcode {
+	return types.Barcode(c.barcode)
+}
+
+// DigitableLine returns the digitable line, 47 digits for boletos and 48 for convenios, with every check digit.
+func (c Code) DigitableLine() types.DigitableLine {
+	b := c.barcode
+	switch c.Kind() {
+	case KindBoleto:
+		f1, f2, f3 := b[0:4]+b[19:24], b[24:34], b[34:44]
+		return types.DigitableLine(f1 + strconv.Itoa(mod10(f1)) + f2 + strconv.Itoa(mod10(f2)) + f3 +
+			strconv.Itoa(mod10(f3)) + b[4:19])
+	case KindConvenio:
+		var line strings.Builder
+		for i := 0; i < 4; i++ {
+			block := b[i*11 : i*11+11]
+			line.WriteString(block)
+			line.WriteString(strconv.Itoa(convenioDigit(b[2], block)))
+		}
+		return types.DigitableLine(line.String())
+	}
+	return ""
+}
+
+func (c Code) String() string {
+	return c.barcode
+}
+
+// Boleto returns the fields of a boleto barcode, or false for convenios.
+func (c Code) Boleto() (Boleto, bool) {
+	if c.Kind() != KindBoleto {
+		return Boleto{}, false
+	}
+	b := c.barcode
+	return Boleto{
+		BankCode:  b[0:3],
+		Currency:  digit(b[3]),
+		DueFactor: int(atoi(b[5:9])),
+		Amount:    types.Money(atoi(b[9:19])),
+		FreeField: b[19:44],
+	}, true
+}
+
+// Convenio returns the fields of a convenio barcode, or false for boletos.
+func (c Code) Convenio() (Convenio, bool) {
+	if c.Kind() != KindConvenio {
+		return Convenio{}, false
+	}
+	b := c.barcode
+	company := 4
+	if digit(b[1]) == SegmentCNPJ {
+		company = 8
+	}
+	return Convenio{
+		Segment:   digit(b[1]),
+		ValueID:   digit(b[2]),
+		Amount:    types.Money(atoi(b[4:15])),
+		Company:   b[15 : 15+company],
+		FreeField: b[15+company : 44],
+	}, true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func digit(b byte) int {
+	return int(b - '0')
+}
+
+func atoi(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
+
+// pad left pads the decimal representation of v with zeros to width digits, reporting whether it fits.
+func pad(v int64, width int) (string, bool) {
+	s := fmt.Sprintf("%0*d", width, v)
+	return s, v >= 0 && len(s) == width
+}
@@ -0,0 +1,260 @@
+package febraban
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const (
+	boletoLine      = "23790123016000000005325000456704864130000001000"
+	boletoBarcode   = "23798641300000010000123060000000052500045670"
+	convenioLine    = "846700000017435900240209024050002435842210108119"
+	convenioBarcode = "84670000001435900240200240500024384221010811"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Code          string
+		Expected      types.Barcode
+		ExpectedError error
+	}{
+		{Name: "Should parse formatted boleto line", Code: "23790.12301 60000.000053 25000.456704 8 64130000001000", Expected: boletoBarcode},
+		{Name: "Should parse boleto barcode", Code: boletoBarcode, Expected: boletoBarcode},
+		{Name: "Should parse convenio line", Code: convenioLine, Expected: convenioBarcode},
+		{Name: "Should parse convenio barcode", Code: convenioBarcode, Expected: convenioBarcode},
+		{
+			Name:     "Should parse formatted convenio line",
+			Code:     "83640000001-1 33120138000-2 81288462711-6 08013618155-1",
+			Expected: "83640000001331201380008128846271108013618155",
+		},
+		{
+			Name:     "Should parse convenio line with modulo 11 check digits",
+			Code:     "85890000460-9 52460179160-5 60759305086-5 83148300001-0",
+			Expected: "85890000460524601791606075930508683148300001",
+		},
+		{Name: "Should reject boleto field check digit", Code: "23790.12302 60000.000053 25000.456704 8 64130000001000", ExpectedError: ErrCheckDigit},
+		{Name: "Should reject boleto general check digit", Code: "23790.12301 60000.000053 25000.456704 7 64130000001000", ExpectedError: ErrCheckDigit},
+		{Name: "Should reject barcode general check digit", Code: "23797641300000010000123060000000052500045670", ExpectedError: ErrCheckDigit},
+		{Name: "Should reject convenio block check digit", Code: "846700000018435900240209024050002435842210108119", ExpectedError: ErrCheckDigit},
+		{Name: "Should reject convenio general check digit", Code: "84610000001435900240200240500024384221010811", ExpectedError: ErrCheckDigit},
+		{Name: "Should reject unknown value identifier", Code: "84570000001435900240200240500024384221010811", ExpectedError: ErrMalformed},
+		{Name: "Should reject boleto line with 48 digits", Code: "0" + boletoLine, ExpectedError: ErrMalformed},
+		{Name: "Should reject wrong length", Code: boletoBarcode[:43], ExpectedError: ErrMalformed},
+		{Name: "Should reject letters", Code: boletoBarcode[:43] + "A", ExpectedError: ErrMalformed},
+		{Name: "Should reject empty code", Code: "", ExpectedError: ErrMalformed},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			code, err := Parse(testCase.Code)
+
+			// Asserts
+			if !errors.Is(err, testCase.ExpectedError) || (testCase.ExpectedError == nil && err != nil) {
+				t.Fatalf("Parse() error = %v, expected %v", err, testCase.ExpectedError)
+			}
+			if code.Barcode() != testCase.Expected {
+				t.Errorf("Parse() = %s, expected %s", code.Barcode(), testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestDigitableLine(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Barcode   string
+		Expected  types.DigitableLine
+		Formatted string
+	}{
+		{
+			Name:      "Should build boleto line",
+			Barcode:   boletoBarcode,
+			Expected:  boletoLine,
+			Formatted: "23790.12301 60000.000053 25000.456704 8 64130000001000",
+		},
+		{
+			Name:      "Should build convenio line",
+			Barcode:   "83640000001331201380008128846271108013618155",
+			Expected:  "836400000011331201380002812884627116080136181551",
+			Formatted: "83640000001-1 33120138000-2 81288462711-6 08013618155-1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			code, err := ParseBarcode(testCase.Barcode)
+			if err != nil {
+				t.Fatalf("ParseBarcode() error = %v", err)
+			}
+
+			// Act
+			line := code.DigitableLine()
+
+			// Asserts
+			if line != testCase.Expected || line.Formatted() != testCase.Formatted {
+				t.Errorf("DigitableLine() = %s, expected %s", line.Formatted(), testCase.Formatted)
+			}
+		})
+	}
+}
+
+func TestBoleto(t *testing.T) {
+	code, _ := Parse(boletoLine)
+
+	boleto, ok := code.Boleto()
+	if !ok || code.Kind() != KindBoleto {
+		t.Fatalf("Boleto() of %s is not a boleto", code)
+	}
+	expected := Boleto{BankCode: "237", Currency: CurrencyBRL, DueFactor: 6413, Amount: 1000, FreeField: "0123060000000052500045670"}
+	if boleto != expected {
+		t.Errorf("Boleto() = %+v, expected %+v", boleto, expected)
+	}
+	if _, ok := code.Convenio(); ok {
+		t.Error("Convenio() of a boleto succeeded")
+	}
+
+	built, err := boleto.Code()
+	if err != nil || built != code {
+		t.Errorf("Code() = %s, %v, expected %s", built, err, code)
+	}
+
+	for _, invalid := range []Boleto{
+		{BankCode: "23", Currency: 9, FreeField: expected.FreeField},
+		{BankCode: "847", Currency: 9, FreeField: expected.FreeField},
+		{BankCode: "237", Currency: 10, FreeField: expected.FreeField},
+		{BankCode: "237", Currency: 9, DueFactor: 10000, FreeField: expected.FreeField},
+		{BankCode: "237", Currency: 9, Amount: 10_000_000_000, FreeField: expected.FreeField},
+		{BankCode: "237", Currency: 9, Amount: -1, FreeField: expected.FreeField},
+		{BankCode: "237", Currency: 9, FreeField: "123"},
+	} {
+		if _, err := invalid.Code(); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Code() of %+v error = %v, expected ErrMalformed", invalid, err)
+		}
+	}
+}
+
+func TestConvenio(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Code     string
+		Expected Convenio
+	}{
+		{
+			Name:     "Should extract telecom convenio",
+			Code:     convenioLine,
+			Expected: Convenio{Segment: SegmentTelecom, ValueID: ValueReaisMod10, Amount: 14359, Company: "0024", FreeField: "0200240500024384221010811"},
+		},
+		{
+			Name:     "Should extract government convenio",
+			Code:     "85890000460-9 52460179160-5 60759305086-5 83148300001-0",
+			Expected: Convenio{Segment: SegmentGovernment, ValueID: ValueReaisMod11, Amount: 4605246, Company: "0179", FreeField: "1606075930508683148300001"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			code, err := Parse(testCase.Code)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			// Act
+			convenio, ok := code.Convenio()
+
+			// Asserts
+			if !ok || convenio != testCase.Expected {
+				t.Errorf("Convenio() = %+v, expected %+v", convenio, testCase.Expected)
+			}
+			if built, err := convenio.Code(); err != nil || built != code {
+				t.Errorf("Code() = %s, %v, expected %s", built, err, code)
+			}
+		})
+	}
+}
+
+func TestConvenioCode(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Convenio      Convenio
+		ExpectedError bool
+	}{
+		{Name: "Should build CNPJ segment", Convenio: Convenio{Segment: SegmentCNPJ, ValueID: ValueReaisMod11, Amount: 12345, Company: "11222333", FreeField: "000000000000000000001"}},
+		{Name: "Should build reference quantity", Convenio: Convenio{Segment: SegmentCityHall, ValueID: ValueReferenceMod10, Amount: 7, Company: "1234", FreeField: "0000000000000000000000001"}},
+		{Name: "Should require 8 digit company for CNPJ segment", Convenio: Convenio{Segment: SegmentCNPJ, ValueID: ValueReaisMod10, Company: "1234", FreeField: "0000000000000000000000001"}, ExpectedError: true},
+		{Name: "Should reject unknown value identifier", Convenio: Convenio{Segment: SegmentEnergy, ValueID: 5, Company: "1234", FreeField: "0000000000000000000000001"}, ExpectedError: true},
+		{Name: "Should reject segment 0", Convenio: Convenio{ValueID: ValueReaisMod10, Company: "1234", FreeField: "0000000000000000000000001"}, ExpectedError: true},
+		{Name: "Should reject short free field", Convenio: Convenio{Segment: SegmentEnergy, ValueID: ValueReaisMod10, Company: "1234", FreeField: "1"}, ExpectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			code, err := testCase.Convenio.Code()
+
+			// Asserts
+			if testCase.ExpectedError {
+				if !errors.Is(err, ErrMalformed) {
+					t.Errorf("Code() error = %v, expected ErrMalformed", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Code() error = %v", err)
+			}
+			parsed, err := Parse(string(code.DigitableLine()))
+			if err != nil || parsed != code {
+				t.Fatalf("Parse(DigitableLine()) = %s, %v", parsed, err)
+			}
+			if convenio, _ := parsed.Convenio(); convenio != testCase.Convenio {
+				t.Errorf("Convenio() = %+v, expected %+v", convenio, testCase.Convenio)
+			}
+		})
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Factor   int
+		Ref      time.Time
+		Expected string
+	}{
+		{Name: "Should resolve first cycle", Factor: 6413, Ref: time.Date(2015, time.April, 1, 0, 0, 0, 0, time.UTC), Expected: "2015-04-29"},
+		{Name: "Should resolve first factor of first cycle", Factor: 1000, Ref: time.Date(2000, time.July, 1, 0, 0, 0, 0, time.UTC), Expected: "2000-07-03"},
+		{Name: "Should resolve last factor before rollover", Factor: 9999, Ref: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Expected: "2025-02-21"},
+		{Name: "Should resolve rollover", Factor: 1000, Ref: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), Expected: "2025-02-22"},
+		{Name: "Should resolve second cycle", Factor: 1500, Ref: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), Expected: "2026-07-07"},
+		{Name: "Should resolve late payment of first cycle", Factor: 9900, Ref: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), Expected: "2024-11-14"},
+		{Name: "Should resolve factors below 1000", Factor: 1, Ref: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), Expected: "1997-10-08"},
+		{Name: "Should report missing due date", Factor: 0, Ref: time.Now()},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			date, ok := Boleto{DueFactor: testCase.Factor}.DueDate(testCase.Ref)
+
+			// Asserts
+			if ok != (testCase.Expected != "") || (ok && date.String() != testCase.Expected) {
+				t.Fatalf("DueDate() = %s, %v, expected %s", date, ok, testCase.Expected)
+			}
+			if !ok {
+				return
+			}
+			if factor, _ := DueFactor(date); factor != testCase.Factor {
+				t.Errorf("DueFactor(%s) = %d, expected %d", date, factor, testCase.Factor)
+			}
+		})
+	}
+
+	if _, ok := DueFactor(types.NewDate(1997, time.October, 7)); ok {
+		t.Error("DueFactor() of the base date succeeded")
+	}
+}
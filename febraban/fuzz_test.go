@@ -0,0 +1,55 @@
+package febraban
+
+import (
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func FuzzParse(f *testing.F) {
+	f.Add(boletoLine)
+	f.Add(boletoBarcode)
+	f.Add(convenioLine)
+	f.Add("85890000460-9 52460179160-5 60759305086-5 83148300001-0")
+	f.Add("2379")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		code, err := Parse(s)
+		if err != nil {
+			return
+		}
+
+		// Every parsed code must be rebuilt from its fields and from its digitable line.
+		var built Code
+		if boleto, ok := code.Boleto(); ok {
+			built, err = boleto.Code()
+		} else if convenio, ok := code.Convenio(); ok {
+			built, err = convenio.Code()
+		}
+		if err != nil || built != code {
+			t.Fatalf("rebuilding %s gave %s, %v", code, built, err)
+		}
+
+		line, err := Parse(string(code.DigitableLine()))
+		if err != nil || line != code {
+			t.Fatalf("Parse(DigitableLine()) of %s = %s, %v", code, line, err)
+		}
+	})
+}
+
+func FuzzBoletoCode(f *testing.F) {
+	f.Add("237", 9, 6413, int64(1000), "0123060000000052500045670")
+	f.Add("001", 9, 0, int64(0), "0000000000000000000000000")
+
+	f.Fuzz(func(t *testing.T, bank string, currency, factor int, amount int64, free string) {
+		code, err := Boleto{BankCode: bank, Currency: currency, DueFactor: factor, Amount: types.Money(amount), FreeField: free}.Code()
+		if err != nil {
+			return
+		}
+
+		parsed, err := ParseBarcode(string(code.Barcode()))
+		if err != nil || parsed != code {
+			t.Fatalf("ParseBarcode(%s) = %s, %v", code, parsed, err)
+		}
+	})
+}
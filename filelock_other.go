@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package openbank
+
+import (
+	"errors"
+	"os"
+)
+
+var errFileLockUnsupported = errors.New("openbank: file locks are not supported on this platform")
+
+func tryLockFile(*os.File) (bool, error) {
+	return false, errFileLockUnsupported
+}
+
+func unlockFile(*os.File) error {
+	return errFileLockUnsupported
+}
@@ -10,9 +10,7 @@ require (
 	go.opentelemetry.io/otel v1.38.0
 	go.opentelemetry.io/otel/trace v1.38.0
 	golang.org/x/oauth2 v0.31.0
+	golang.org/x/sys v0.36.0
 )
 
-require (
-	golang.org/x/crypto v0.42.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
-)
+require golang.org/x/crypto v0.42.0 // indirect
@@ -0,0 +1,202 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const (
+	jwksPath = "/api/v1/discovery/keys"
+
+	// jwksMinRefreshInterval rate limits refreshes triggered by unknown key IDs.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// jwksCache keeps StonePublicKeys in sync with Stone's JWKS document.
+type jwksCache struct {
+	// m guards Client.StonePublicKeys and the timestamps below.
+	m sync.RWMutex
+	// refresh serializes fetches so concurrent misses share a single request.
+	refresh sync.Mutex
+
+	enabled bool
+	pinned  bool
+	url     *url.URL
+	file    string
+	ttl     time.Duration
+
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// WithJWKSRefresh makes the client fetch StonePublicKeys from Stone's JWKS document, caching it for ttl. The document
+// is also fetched again when a webhook is signed with an unknown kid, at most once a minute.
+func WithJWKSRefresh(ttl time.Duration) ClientOpt {
+	return func(c *Client) {
+		c.jwks.enabled = true
+		c.jwks.ttl = ttl
+	}
+}
+
+// SetJWKSURL overrides the URL of Stone's JWKS document, which defaults to the discovery endpoint of ApiBaseURL.
+func SetJWKSURL(newJWKSUrl string) (ClientOpt, error) {
+	jwksURL, err := url.Parse(newJWKSUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwks url: %w", err)
+	}
+	return func(c *Client) {
+		c.jwks.url = jwksURL
+	}, nil
+}
+
+// WithJWKSFile pins StonePublicKeys to the JWKS document stored at path, disabling any fetch. Useful for air-gapped
+// tests.
+func WithJWKSFile(path string) ClientOpt {
+	return func(c *Client) {
+		c.jwks.file = path
+		c.jwks.pinned = true
+	}
+}
+
+// LoadJWKSFile replaces StonePublicKeys with the keys of the JWKS document stored at path.
+func (c *Client) LoadJWKSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("invalid jwks file %s: %w", path, err)
+	}
+
+	c.setStonePublicKeys(keys, time.Now())
+	return nil
+}
+
+// RefreshStonePublicKeys fetches Stone's JWKS document and replaces StonePublicKeys with its keys. The request goes
+// through the client HTTP client, user agent and tracing.
+func (c *Client) RefreshStonePublicKeys(ctx context.Context) error {
+	u := c.jwks.url
+	if u == nil {
+		var err error
+		if u, err = c.ApiBaseURL.Parse(jwksPath); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("User-Agent", c.UserAgent)
+	req = req.WithContext(ctx)
+
+	var body bytes.Buffer
+	if _, err := c.do(req, &body, nil, false); err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(body.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid jwks document at %s: %w", u, err)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("jwks document at %s has no usable keys", u)
+	}
+
+	c.setStonePublicKeys(keys, time.Now())
+	return nil
+}
+
+// stonePublicKey returns the key for kid, fetching the JWKS document when the cache expired or kid is unknown.
+func (c *Client) stonePublicKey(ctx context.Context, kid string) *jose.JSONWebKey {
+	if kid == "" {
+		return nil
+	}
+
+	key, stale := c.cachedStonePublicKey(kid)
+	if !c.jwks.enabled || c.jwks.pinned || (key != nil && !stale) {
+		return key
+	}
+
+	c.jwks.refresh.Lock()
+	defer c.jwks.refresh.Unlock()
+
+	// Another caller may have refreshed the keys while we waited.
+	key, stale = c.cachedStonePublicKey(kid)
+	if key != nil && !stale {
+		return key
+	}
+
+	c.jwks.m.RLock()
+	attemptedAt := c.jwks.attemptedAt
+	c.jwks.m.RUnlock()
+	if time.Since(attemptedAt) < jwksMinRefreshInterval {
+		return key
+	}
+
+	c.jwks.m.Lock()
+	c.jwks.attemptedAt = time.Now()
+	c.jwks.m.Unlock()
+
+	if err := c.RefreshStonePublicKeys(ctx); err != nil {
+		c.log.Error(fmt.Errorf("refreshing stone public keys error %s", err))
+		return key
+	}
+
+	key, _ = c.cachedStonePublicKey(kid)
+	return key
+}
+
+func (c *Client) cachedStonePublicKey(kid string) (key *jose.JSONWebKey, stale bool) {
+	c.jwks.m.RLock()
+	defer c.jwks.m.RUnlock()
+
+	stale = c.jwks.fetchedAt.IsZero() || (c.jwks.ttl > 0 && time.Since(c.jwks.fetchedAt) > c.jwks.ttl)
+	return c.StonePublicKeys.Get(kid), stale
+}
+
+func (c *Client) setStonePublicKeys(keys types.StonePublicKeys, fetchedAt time.Time) {
+	c.jwks.m.Lock()
+	defer c.jwks.m.Unlock()
+
+	c.StonePublicKeys = keys
+	c.jwks.fetchedAt = fetchedAt
+}
+
+func parseJWKS(data []byte) (types.StonePublicKeys, error) {
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(types.StonePublicKeys, len(set.Keys))
+	for i := range set.Keys {
+		addJWK(keys, &set.Keys[i])
+	}
+	return keys, nil
+}
+
+// addJWK adds the public signing keys of a JWKS document, indexed by kid.
+func addJWK(keys types.StonePublicKeys, key *jose.JSONWebKey) {
+	if key.KeyID == "" || (key.Use != "" && key.Use != "sig") {
+		return
+	}
+	public := key.Public()
+	if !public.Valid() {
+		return
+	}
+	keys[key.KeyID] = &public
+}
@@ -0,0 +1,130 @@
+package openbank
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+func testJWKS(t *testing.T, kids ...string) []byte {
+	t.Helper()
+
+	set := jose.JSONWebKeySet{}
+	for _, kid := range kids {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("error generating key: %v", err)
+		}
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: "RS256", Use: "sig"})
+	}
+	data, _ := json.Marshal(set)
+	return data
+}
+
+func newJWKSServer(t *testing.T, documents ...[]byte) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != jwksPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("expected User-Agent header")
+		}
+		n := int(calls.Add(1))
+		if n > len(documents) {
+			n = len(documents)
+		}
+		_, _ = w.Write(documents[n-1])
+	}))
+	t.Cleanup(s.Close)
+
+	return s, &calls
+}
+
+func TestStonePublicKeyFetchesOnUnknownKid(t *testing.T) {
+	s, calls := newJWKSServer(t, testJWKS(t, "key-1"), testJWKS(t, "key-2"))
+	baseURL, _ := SetBaseURL(s.URL)
+	c, _ := NewClient(baseURL, WithJWKSRefresh(time.Hour))
+	ctx := context.Background()
+
+	if key := c.stonePublicKey(ctx, "key-1"); key == nil {
+		t.Fatal("expected key-1 to be fetched")
+	}
+	if key := c.stonePublicKey(ctx, "key-1"); key == nil {
+		t.Fatal("expected key-1 to be cached")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("jwks requests = %d, expected 1", got)
+	}
+
+	// key-2 was not fetched yet, and refreshes are rate limited.
+	if key := c.stonePublicKey(ctx, "key-2"); key != nil {
+		t.Error("expected rate limited lookup of key-2 to fail")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("jwks requests = %d, expected 1", got)
+	}
+
+	c.jwks.attemptedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+	if key := c.stonePublicKey(ctx, "key-2"); key == nil {
+		t.Error("expected key-2 after rotation")
+	}
+	if key, _ := c.cachedStonePublicKey("key-1"); key != nil {
+		t.Error("expected rotated key-1 to be dropped")
+	}
+}
+
+func TestStonePublicKeyRefreshesAfterTTL(t *testing.T) {
+	s, calls := newJWKSServer(t, testJWKS(t, "key-1"))
+	baseURL, _ := SetBaseURL(s.URL)
+	c, _ := NewClient(baseURL, WithJWKSRefresh(time.Minute))
+	ctx := context.Background()
+
+	_ = c.stonePublicKey(ctx, "key-1")
+	c.jwks.fetchedAt = time.Now().Add(-2 * time.Minute)
+	c.jwks.attemptedAt = c.jwks.fetchedAt
+
+	if key := c.stonePublicKey(ctx, "key-1"); key == nil {
+		t.Fatal("expected key-1")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("jwks requests = %d, expected 2", got)
+	}
+}
+
+func TestWithJWKSFilePinsKeys(t *testing.T) {
+	s, calls := newJWKSServer(t, testJWKS(t, "remote-key"))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, testJWKS(t, "pinned-key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	baseURL, _ := SetBaseURL(s.URL)
+	c, err := NewClient(baseURL, WithJWKSRefresh(time.Hour), WithJWKSFile(path))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if key := c.stonePublicKey(context.Background(), "pinned-key"); key == nil {
+		t.Error("expected pinned key")
+	}
+	if key := c.stonePublicKey(context.Background(), "remote-key"); key != nil {
+		t.Error("expected remote key not to be fetched")
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("jwks requests = %d, expected 0", got)
+	}
+}
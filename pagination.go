@@ -0,0 +1,58 @@
+package openbank
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// paginate iterates lazily over a cursor paginated listing, starting at the cursor after. fetch returns the items of
+// the page at a cursor and the cursor of the next page. Iteration ends at the last page, whose next cursor is empty or
+// repeated, and stops at the first error, which is yielded with the zero item.
+func paginate[T any](after string, fetch func(after string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, next, err := fetch(after)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if next == "" || next == after {
+				return
+			}
+			after = next
+		}
+	}
+}
+
+// fetchPage gets a page of the listing at path, returning its items and the cursor of the next page.
+func fetchPage[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, string, error) {
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+
+	req, err := c.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page struct {
+		Cursor types.Cursor `json:"cursor"`
+		Data   []T          `json:"data"`
+	}
+	if _, err := c.Do(req.WithContext(ctx), &page, nil); err != nil {
+		return nil, "", err
+	}
+
+	return page.Data, page.Cursor.After, nil
+}
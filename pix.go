@@ -0,0 +1,144 @@
+package openbank
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// PixService handles PIX payments.
+type PixService struct {
+	client *Client
+
+	m       sync.Mutex
+	refunds map[string]*refundGuard
+	// unresolvedRefunds holds the entry IDs and idempotency keys of refunds whose outcome is unknown.
+	unresolvedRefunds map[string]bool
+}
+
+// LookupKey fetches the account a DICT key points to, so the beneficiary can be confirmed before paying with
+// PayKey. The returned EndToEndID identifies the payment and must be sent back in PayKey.
+func (s *PixService) LookupKey(ctx context.Context, accountID, key string) (*types.PixKeyLookup, *Response, error) {
+	path, err := apiPath("/api/v1/pix/%s/entries/%s", accountID, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lookup types.PixKeyLookup
+	resp, err := s.client.Do(req.WithContext(ctx), &lookup, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &lookup, resp, nil
+}
+
+// PayKey pays a DICT key looked up with LookupKey. Idempotency keys are handled as in TransfersService.CreateInternal.
+func (s *PixService) PayKey(ctx context.Context, input *types.PixKeyPaymentInput) (*types.PixEntry, *Response, error) {
+	var errs validationErrors
+	errs.payment(input.AccountID, input.Amount)
+	if input.Key == "" {
+		errs.add("is required", "key")
+	}
+	if input.EndToEndID == "" {
+		errs.add("is required, look the key up first", "end_to_end_id")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.pay(ctx, "/api/v1/pix/outbound_pix_payments", input, &input.IdempotencyKey)
+}
+
+// PayManual pays an account given by its ISPB, branch and account code. The beneficiary is validated locally as in
+// TransfersService.CreateExternal.
+func (s *PixService) PayManual(ctx context.Context, input *types.PixManualPaymentInput) (*types.PixEntry, *Response, error) {
+	var errs validationErrors
+	errs.payment(input.AccountID, input.Amount)
+	errs.beneficiary(input.Beneficiary, true)
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+	inferDocumentType(&input.Beneficiary.Entity)
+
+	return s.pay(ctx, "/api/v1/pix/outbound_pix_payments", input, &input.IdempotencyKey)
+}
+
+// PayBRCode pays a BR Code, the "copia e cola" string of a PIX QR code.
+func (s *PixService) PayBRCode(ctx context.Context, input *types.PixBRCodePaymentInput) (*types.PixEntry, *Response, error) {
+	var errs validationErrors
+	if input.AccountID == "" {
+		errs.add("is required", "account_id")
+	}
+	if input.Amount < 0 {
+		errs.add("must not be negative", "amount")
+	}
+	if strings.TrimSpace(input.BRCode) == "" {
+		errs.add("is required", "brcode")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.pay(ctx, "/api/v1/pix/outbound_pix_payments/brcode", input, &input.IdempotencyKey)
+}
+
+func (s *PixService) pay(ctx context.Context, path string, input interface{}, idempotencyKey *string) (*types.PixEntry, *Response, error) {
+	var entry types.PixEntry
+	resp, err := s.client.postIdempotent(ctx, path, input, idempotencyKey, &entry)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &entry, resp, nil
+}
+
+// GetPayment returns the outbound PIX payment identified by paymentID.
+func (s *PixService) GetPayment(ctx context.Context, paymentID string) (*types.PixEntry, *Response, error) {
+	path, err := apiPath("/api/v1/pix/outbound_pix_payments/%s", paymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entry types.PixEntry
+	resp, err := s.client.Do(req.WithContext(ctx), &entry, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &entry, resp, nil
+}
+
+// AwaitPayment polls the payment every interval until it reaches a final status or ctx is done.
+func (s *PixService) AwaitPayment(ctx context.Context, paymentID string, interval time.Duration) (*types.PixEntry, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		entry, _, err := s.GetPayment(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Final() {
+			return entry, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return entry, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package openbank
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// CreateCharge creates an immediate charge: a dynamic QR code for a fixed amount, valid for input.Expiration seconds.
+// The returned charge carries the payload location and its BR Code.
+func (s *PixService) CreateCharge(ctx context.Context, input *types.PixChargeInput) (*types.PixCharge, *Response, error) {
+	var errs validationErrors
+	errs.charge(input.AccountID, input.Key, input.Amount)
+	if input.Expiration < 0 {
+		errs.add("must not be negative", "expiration")
+	}
+	if input.Payer != nil {
+		errs.payer(input.Payer.Name, input.Payer.Document)
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.createCharge(ctx, "/api/v1/pix/charges", input, &input.IdempotencyKey)
+}
+
+// CreateDueCharge creates a due-date charge, with optional fine, interest and discounts.
+func (s *PixService) CreateDueCharge(ctx context.Context, input *types.PixDueChargeInput) (*types.PixCharge, *Response, error) {
+	var errs validationErrors
+	errs.charge(input.AccountID, input.Key, input.Amount)
+	if input.DueDate.IsZero() {
+		errs.add("is required", "due_date")
+	}
+	if input.ValidityAfterDue < 0 {
+		errs.add("must not be negative", "validity_after_due")
+	}
+	errs.payer(input.Payer.Name, input.Payer.Document)
+	errs.dueRules(input.Amount, input.DueDate, input.Fine, input.Interest, input.Discounts)
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.createCharge(ctx, "/api/v1/pix/due_charges", input, &input.IdempotencyKey)
+}
+
+func (s *PixService) createCharge(ctx context.Context, path string, input interface{}, idempotencyKey *string) (*types.PixCharge, *Response, error) {
+	var charge types.PixCharge
+	resp, err := s.client.postIdempotent(ctx, path, input, idempotencyKey, &charge)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &charge, resp, nil
+}
+
+// GetCharge returns the charge identified by chargeID, immediate or with a due date.
+func (s *PixService) GetCharge(ctx context.Context, chargeID string) (*types.PixCharge, *Response, error) {
+	path, err := apiPath("/api/v1/pix/charges/%s", chargeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var charge types.PixCharge
+	resp, err := s.client.Do(req.WithContext(ctx), &charge, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &charge, resp, nil
+}
+
+// ListCharges iterates lazily over the charges of the account, following the pagination cursor. Iteration stops at
+// the first error.
+func (s *PixService) ListCharges(ctx context.Context, accountID string, filter types.PixChargeFilter) iter.Seq2[types.PixCharge, error] {
+	path, err := apiPath("/api/v1/accounts/%s/pix/charges", accountID)
+	return paginate(filter.After, func(after string) ([]types.PixCharge, string, error) {
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+		return fetchPage[types.PixCharge](ctx, s.client, path, chargeQuery(filter))
+	})
+}
+
+// CancelCharge cancels an active charge, so that its QR code can no longer be paid.
+func (s *PixService) CancelCharge(ctx context.Context, chargeID string) (*Response, error) {
+	path, err := apiPath("/api/v1/pix/charges/%s/cancel", chargeID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req.WithContext(ctx), nil, nil)
+}
+
+func chargeQuery(filter types.PixChargeFilter) url.Values {
+	q := url.Values{}
+	if filter.Status != "" {
+		q.Set("status", filter.Status)
+	}
+	if !filter.Start.IsZero() {
+		q.Set("start_datetime", filter.Start.Format(time.RFC3339))
+	}
+	if !filter.End.IsZero() {
+		q.Set("end_datetime", filter.End.Format(time.RFC3339))
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.After != "" {
+		q.Set("after", filter.After)
+	}
+	return q
+}
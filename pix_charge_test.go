@@ -0,0 +1,172 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/brcode"
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const testChargeBRCode = "00020101021226520014br.gov.bcb.pix2530pix.example.com/qr/v2/9d36b84f5204000053039865802BR5904Loja6006RECIFE62070503***630412C6"
+
+func TestPixCreateCharges(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]interface{}
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"id":"ch-1","status":"ACTIVE","amount":5000,"expiration":3600,"created_at":"2025-03-01T12:00:00Z",` +
+			`"location":"pix.example.com/qr/v2/9d36b84f","brcode":"` + testChargeBRCode + `"}`))
+	})
+	ctx := context.Background()
+	payer := types.PixPayer{Name: "Fulano", Document: "529.982.247-25"}
+	dueDate := types.NewDate(2025, time.March, 31)
+
+	testCases := []struct {
+		Name          string
+		Create        func() (*types.PixCharge, error)
+		ExpectedPath  string
+		ExpectedField string
+		ExpectedError string
+	}{
+		{
+			Name: "Should create immediate charge",
+			Create: func() (*types.PixCharge, error) {
+				charge, _, err := c.Pix.CreateCharge(ctx, &types.PixChargeInput{AccountID: "acc-1", Key: "loja@example.com", Amount: 5000, Expiration: 3600})
+				return charge, err
+			},
+			ExpectedPath:  "/api/v1/pix/charges",
+			ExpectedField: "expiration",
+		},
+		{
+			Name: "Should create due-date charge",
+			Create: func() (*types.PixCharge, error) {
+				charge, _, err := c.Pix.CreateDueCharge(ctx, &types.PixDueChargeInput{
+					AccountID: "acc-1", Key: "loja@example.com", Amount: 5000, DueDate: dueDate, Payer: payer,
+					Fine:      &types.ChargeFine{Percentage: 200},
+					Interest:  &types.ChargeInterest{Percentage: 100, Period: types.InterestMonthly},
+					Discounts: []types.ChargeDiscount{{Until: types.NewDate(2025, time.March, 20), Amount: 500}},
+				})
+				return charge, err
+			},
+			ExpectedPath:  "/api/v1/pix/due_charges",
+			ExpectedField: "due_date",
+		},
+		{
+			Name: "Should validate payer document",
+			Create: func() (*types.PixCharge, error) {
+				charge, _, err := c.Pix.CreateDueCharge(ctx, &types.PixDueChargeInput{
+					AccountID: "acc-1", Key: "loja@example.com", Amount: 5000, DueDate: dueDate, Payer: types.PixPayer{Name: "Fulano", Document: "123"},
+				})
+				return charge, err
+			},
+			ExpectedError: "payer.document",
+		},
+		{
+			Name: "Should reject fine with amount and percentage",
+			Create: func() (*types.PixCharge, error) {
+				charge, _, err := c.Pix.CreateDueCharge(ctx, &types.PixDueChargeInput{
+					AccountID: "acc-1", Key: "loja@example.com", Amount: 5000, DueDate: dueDate, Payer: payer,
+					Fine: &types.ChargeFine{Amount: 100, Percentage: 200},
+				})
+				return charge, err
+			},
+			ExpectedError: "fine",
+		},
+		{
+			Name: "Should reject discount after due date",
+			Create: func() (*types.PixCharge, error) {
+				charge, _, err := c.Pix.CreateDueCharge(ctx, &types.PixDueChargeInput{
+					AccountID: "acc-1", Key: "loja@example.com", Amount: 5000, DueDate: dueDate, Payer: payer,
+					Discounts: []types.ChargeDiscount{{Until: types.NewDate(2025, time.April, 1), Percentage: 500}},
+				})
+				return charge, err
+			},
+			ExpectedError: "discounts.0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			gotPath, gotBody = "", nil
+
+			// Act
+			charge, err := testCase.Create()
+
+			// Asserts
+			if testCase.ExpectedError != "" {
+				if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), testCase.ExpectedError+":") || gotPath != "" {
+					t.Errorf("expected local validation error on %s, got %v", testCase.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if gotPath != testCase.ExpectedPath {
+				t.Errorf("path = %s, expected %s", gotPath, testCase.ExpectedPath)
+			}
+			if _, ok := gotBody[testCase.ExpectedField]; !ok {
+				t.Errorf("body %v misses %s", gotBody, testCase.ExpectedField)
+			}
+			if charge.Location == "" || !charge.ExpiresAt().Equal(time.Date(2025, 3, 1, 13, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected charge %+v", charge)
+			}
+			code, err := brcode.Decode(charge.BRCode)
+			if err != nil || code.URL != charge.Location {
+				t.Errorf("BR Code %+v does not point to %s: %v", code, charge.Location, err)
+			}
+		})
+	}
+}
+
+func TestPixCharges(t *testing.T) {
+	var canceled bool
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/pix/charges/ch-1":
+			_, _ = w.Write([]byte(`{"id":"ch-1","status":"COMPLETED","due_date":"2025-03-31","end_to_end_id":"E1"}`))
+		case "/api/v1/pix/charges/ch-1/cancel":
+			canceled = r.Method == http.MethodPost
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/v1/accounts/acc-1/pix/charges":
+			if r.URL.Query().Get("after") == "" {
+				_, _ = w.Write([]byte(`{"cursor":{"after":"c1"},"data":[{"id":"ch-1"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"cursor":{},"data":[{"id":"ch-2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	charge, _, err := c.Pix.GetCharge(ctx, "ch-1")
+	if err != nil || charge.DueDate.String() != "2025-03-31" || !charge.ExpiresAt().IsZero() {
+		t.Errorf("GetCharge() = %+v, %v", charge, err)
+	}
+
+	var ids []string
+	for charge, err := range c.Pix.ListCharges(ctx, "acc-1", types.PixChargeFilter{Status: types.PixChargeStatusActive}) {
+		if err != nil {
+			t.Fatalf("ListCharges() error = %v", err)
+		}
+		ids = append(ids, charge.ID)
+	}
+	if strings.Join(ids, ",") != "ch-1,ch-2" {
+		t.Errorf("listed %v", ids)
+	}
+
+	if _, err := c.Pix.CancelCharge(ctx, "ch-1"); err != nil || !canceled {
+		t.Errorf("CancelCharge() error = %v", err)
+	}
+}
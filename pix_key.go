@@ -0,0 +1,220 @@
+package openbank
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// Key formats accepted by the DICT.
+var (
+	pixKeyCPFRegexp   = regexp.MustCompile(`^\d{11}$`)
+	pixKeyCNPJRegexp  = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+	pixKeyPhoneRegexp = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+	pixKeyEmailRegexp = regexp.MustCompile(`^[a-z0-9.!#$&'*+/=?^_` + "`" + `{|}~-]+@[a-z0-9-]+(\.[a-z0-9-]+)*$`)
+	pixKeyEVPRegexp   = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// PixKeysService handles the PIX keys of the accounts in the DICT, the directory of PIX keys.
+type PixKeysService struct {
+	client *Client
+}
+
+// ValidatePixKey checks key against the DICT format of keyType: unformatted CPF and CNPJ, including alphanumeric
+// CNPJs, with valid check digits, E.164 for phones (e.g. +5511999999999), lowercase e-mails up to 77 characters and
+// lowercase UUIDs for EVP. Invalid keys fail with ErrValidation.
+func ValidatePixKey(keyType types.PixKeyType, key string) error {
+	var errs validationErrors
+	errs.pixKey(keyType, key, "key")
+	return errs.err()
+}
+
+func (v *validationErrors) pixKey(keyType types.PixKeyType, key string, path ...string) {
+	var valid bool
+	switch keyType {
+	case types.PixKeyCPF:
+		valid = pixKeyCPFRegexp.MatchString(key) && types.CPF(key).Valid()
+	case types.PixKeyCNPJ:
+		valid = pixKeyCNPJRegexp.MatchString(key) && types.CNPJ(key).Valid()
+	case types.PixKeyPhone:
+		valid = pixKeyPhoneRegexp.MatchString(key)
+	case types.PixKeyEmail:
+		valid = len(key) <= 77 && pixKeyEmailRegexp.MatchString(key)
+	case types.PixKeyEVP:
+		valid = pixKeyEVPRegexp.MatchString(key)
+	default:
+		v.add(fmt.Sprintf("invalid key type %q", keyType), "key_type")
+		return
+	}
+	if !valid {
+		v.add(fmt.Sprintf("invalid %s key", keyType), path...)
+	}
+}
+
+// Register registers a key for the account. E-mail and phone keys stay PixKeyStatusPending until their ownership is
+// confirmed. The key is validated locally first, see ValidatePixKey.
+func (s *PixKeysService) Register(ctx context.Context, input *types.PixKeyInput) (*types.PixKey, *Response, error) {
+	var errs validationErrors
+	if input.AccountID == "" {
+		errs.add("is required", "account_id")
+	}
+	if input.Type == types.PixKeyEVP {
+		if input.Key != "" {
+			errs.add("must be empty, random keys are generated by the DICT", "key")
+		}
+	} else {
+		errs.pixKey(input.Type, input.Key, "key")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	var key types.PixKey
+	path, err := apiPath("/api/v1/accounts/%s/pix_keys", input.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.postIdempotent(ctx, path, input, &input.IdempotencyKey, &key)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &key, resp, nil
+}
+
+// List returns the keys of the account.
+func (s *PixKeysService) List(ctx context.Context, accountID string) ([]types.PixKey, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/pix_keys", accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keys []types.PixKey
+	resp, err := s.client.Do(req.WithContext(ctx), &keys, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return keys, resp, nil
+}
+
+// Delete removes the key identified by keyID from the DICT.
+func (s *PixKeysService) Delete(ctx context.Context, accountID, keyID string) (*Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/pix_keys/%s", accountID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req.WithContext(ctx), nil, nil)
+}
+
+// CreateClaim opens a portability or ownership claim over a key registered elsewhere. Only e-mail and phone keys can
+// be claimed by ownership.
+func (s *PixKeysService) CreateClaim(ctx context.Context, input *types.PixKeyClaimInput) (*types.PixKeyClaim, *Response, error) {
+	var errs validationErrors
+	if input.AccountID == "" {
+		errs.add("is required", "account_id")
+	}
+	errs.pixKey(input.KeyType, input.Key, "key")
+	switch input.Type {
+	case types.ClaimPortability:
+	case types.ClaimOwnership:
+		if input.KeyType != types.PixKeyEmail && input.KeyType != types.PixKeyPhone {
+			errs.add("only e-mail and phone keys can be claimed by ownership", "claim_type")
+		}
+	default:
+		errs.add(fmt.Sprintf("invalid claim type %q", input.Type), "claim_type")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	var claim types.PixKeyClaim
+	path, err := apiPath("/api/v1/accounts/%s/pix_key_claims", input.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.postIdempotent(ctx, path, input, &input.IdempotencyKey, &claim)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &claim, resp, nil
+}
+
+// ListClaims returns the claims in which the account is the claimer or the donor.
+func (s *PixKeysService) ListClaims(ctx context.Context, accountID string) ([]types.PixKeyClaim, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/pix_key_claims", accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var claims []types.PixKeyClaim
+	resp, err := s.client.Do(req.WithContext(ctx), &claims, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return claims, resp, nil
+}
+
+// ConfirmClaim releases the key to the claimer. It is called by the donor.
+func (s *PixKeysService) ConfirmClaim(ctx context.Context, accountID, claimID string) (*types.PixKeyClaim, *Response, error) {
+	return s.claimAction(ctx, accountID, claimID, "confirm", nil)
+}
+
+// CancelClaim cancels the claim, keeping the key with the donor. Both sides may cancel, with one of the
+// ClaimCancel reasons.
+func (s *PixKeysService) CancelClaim(ctx context.Context, accountID, claimID, reason string) (*types.PixKeyClaim, *Response, error) {
+	switch reason {
+	case types.ClaimCancelUserRequested, types.ClaimCancelAccountClosed, types.ClaimCancelFraud:
+	default:
+		var errs validationErrors
+		errs.add(fmt.Sprintf("invalid cancel reason %q", reason), "reason")
+		return nil, nil, errs.err()
+	}
+
+	body := struct {
+		Reason string `json:"reason"`
+	}{reason}
+	return s.claimAction(ctx, accountID, claimID, "cancel", body)
+}
+
+// CompleteClaim registers the key for the claimer once the claim is confirmed.
+func (s *PixKeysService) CompleteClaim(ctx context.Context, accountID, claimID string) (*types.PixKeyClaim, *Response, error) {
+	return s.claimAction(ctx, accountID, claimID, "complete", nil)
+}
+
+func (s *PixKeysService) claimAction(ctx context.Context, accountID, claimID, action string, body interface{}) (*types.PixKeyClaim, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/pix_key_claims/%s/%s", accountID, claimID, action)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var claim types.PixKeyClaim
+	resp, err := s.client.Do(req.WithContext(ctx), &claim, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &claim, resp, nil
+}
@@ -0,0 +1,135 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestValidatePixKey(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Type     types.PixKeyType
+		Key      string
+		Expected bool
+	}{
+		{Name: "Should accept CPF", Type: types.PixKeyCPF, Key: "52998224725", Expected: true},
+		{Name: "Should reject formatted CPF", Type: types.PixKeyCPF, Key: "529.982.247-25"},
+		{Name: "Should reject CPF check digits", Type: types.PixKeyCPF, Key: "52998224724"},
+		{Name: "Should accept CNPJ", Type: types.PixKeyCNPJ, Key: "11222333000181", Expected: true},
+		{Name: "Should accept alphanumeric CNPJ", Type: types.PixKeyCNPJ, Key: "12ABC34501DE35", Expected: true},
+		{Name: "Should reject lowercase CNPJ", Type: types.PixKeyCNPJ, Key: "12abc34501de35"},
+		{Name: "Should reject CPF as CNPJ", Type: types.PixKeyCNPJ, Key: "52998224725"},
+		{Name: "Should accept E.164 phone", Type: types.PixKeyPhone, Key: "+5511999999999", Expected: true},
+		{Name: "Should reject phone without country code", Type: types.PixKeyPhone, Key: "11999999999"},
+		{Name: "Should accept e-mail", Type: types.PixKeyEmail, Key: "fulano.tal+pix@example.com.br", Expected: true},
+		{Name: "Should reject uppercase e-mail", Type: types.PixKeyEmail, Key: "Fulano@example.com"},
+		{Name: "Should reject long e-mail", Type: types.PixKeyEmail, Key: strings.Repeat("a", 70) + "@example.com"},
+		{Name: "Should accept EVP", Type: types.PixKeyEVP, Key: "123e4567-e12b-12d1-a456-426655440000", Expected: true},
+		{Name: "Should reject uppercase EVP", Type: types.PixKeyEVP, Key: "123E4567-E12B-12D1-A456-426655440000"},
+		{Name: "Should reject unknown type", Type: "iban", Key: "BR00"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := ValidatePixKey(testCase.Type, testCase.Key)
+
+			if (err == nil) != testCase.Expected {
+				t.Errorf("ValidatePixKey(%s, %q) = %v, expected valid %v", testCase.Type, testCase.Key, err, testCase.Expected)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("expected ErrValidation, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPixKeysService(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, nil
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/pix_keys") && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id":"k-1","key":"fulano@example.com","key_type":"email","status":"PENDING"}]`))
+		case strings.HasSuffix(r.URL.Path, "/pix_keys"):
+			_, _ = w.Write([]byte(`{"id":"k-2","key":"123e4567-e12b-12d1-a456-426655440000","key_type":"evp","status":"ACTIVE"}`))
+		case strings.HasSuffix(r.URL.Path, "/pix_key_claims") && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id":"cl-1","claim_type":"portability","status":"WAITING_RESOLUTION","claimer":false}]`))
+		case strings.Contains(r.URL.Path, "/pix_key_claims"):
+			_, _ = w.Write([]byte(`{"id":"cl-1","claim_type":"ownership","status":"CONFIRMED"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	ctx := context.Background()
+
+	t.Run("Should register EVP key", func(t *testing.T) {
+		key, _, err := c.PixKeys.Register(ctx, &types.PixKeyInput{AccountID: "acc-1", Type: types.PixKeyEVP})
+		if err != nil || key.Status != types.PixKeyStatusActive || gotPath != "/api/v1/accounts/acc-1/pix_keys" {
+			t.Errorf("Register() = %+v, %v on %s", key, err, gotPath)
+		}
+	})
+
+	t.Run("Should reject EVP key with value", func(t *testing.T) {
+		gotPath = ""
+		_, _, err := c.PixKeys.Register(ctx, &types.PixKeyInput{AccountID: "acc-1", Type: types.PixKeyEVP, Key: "123e4567-e12b-12d1-a456-426655440000"})
+		if !errors.Is(err, ErrValidation) || gotPath != "" {
+			t.Errorf("expected local ErrValidation, got %v", err)
+		}
+	})
+
+	t.Run("Should list keys", func(t *testing.T) {
+		keys, _, err := c.PixKeys.List(ctx, "acc-1")
+		if err != nil || len(keys) != 1 || keys[0].Type != types.PixKeyEmail {
+			t.Errorf("List() = %+v, %v", keys, err)
+		}
+	})
+
+	t.Run("Should delete key", func(t *testing.T) {
+		_, err := c.PixKeys.Delete(ctx, "acc-1", "k-1")
+		if err != nil || gotMethod != http.MethodDelete || gotPath != "/api/v1/accounts/acc-1/pix_keys/k-1" {
+			t.Errorf("Delete() error = %v, %s %s", err, gotMethod, gotPath)
+		}
+	})
+
+	t.Run("Should reject ownership claim of CPF key", func(t *testing.T) {
+		_, _, err := c.PixKeys.CreateClaim(ctx, &types.PixKeyClaimInput{AccountID: "acc-1", Key: "52998224725", KeyType: types.PixKeyCPF, Type: types.ClaimOwnership})
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	})
+
+	t.Run("Should go through the claim flow", func(t *testing.T) {
+		claim, _, err := c.PixKeys.CreateClaim(ctx, &types.PixKeyClaimInput{AccountID: "acc-1", Key: "+5511999999999", KeyType: types.PixKeyPhone, Type: types.ClaimOwnership})
+		if err != nil || claim.ID != "cl-1" {
+			t.Fatalf("CreateClaim() = %+v, %v", claim, err)
+		}
+
+		claims, _, err := c.PixKeys.ListClaims(ctx, "acc-1")
+		if err != nil || len(claims) != 1 || claims[0].Status != types.ClaimStatusWaitingResolution {
+			t.Errorf("ListClaims() = %+v, %v", claims, err)
+		}
+
+		if _, _, err := c.PixKeys.ConfirmClaim(ctx, "acc-1", "cl-1"); err != nil || gotPath != "/api/v1/accounts/acc-1/pix_key_claims/cl-1/confirm" {
+			t.Errorf("ConfirmClaim() error = %v on %s", err, gotPath)
+		}
+		if claim, _, err := c.PixKeys.CompleteClaim(ctx, "acc-1", "cl-1"); err != nil || claim.Status != types.ClaimStatusConfirmed {
+			t.Errorf("CompleteClaim() = %+v, %v", claim, err)
+		}
+		if _, _, err := c.PixKeys.CancelClaim(ctx, "acc-1", "cl-1", types.ClaimCancelFraud); err != nil || gotBody["reason"] != types.ClaimCancelFraud {
+			t.Errorf("CancelClaim() error = %v, body %v", err, gotBody)
+		}
+		if _, _, err := c.PixKeys.CancelClaim(ctx, "acc-1", "cl-1", "because"); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	})
+}
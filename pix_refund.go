@@ -0,0 +1,201 @@
+package openbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// refundGuard tracks the refunded total of a received PIX entry, so refunds beyond the original amount are rejected
+// before reaching Stone. Its mutex serializes the refunds of the entry. A guard lives while refunds of its entry are in
+// progress: users counts them, under the lock of PixService.
+type refundGuard struct {
+	m        sync.Mutex
+	users    int
+	loaded   bool
+	original types.Money
+	refunded types.Money
+}
+
+// GetEntry returns the PIX entry identified by entryID, sent or received.
+func (s *PixService) GetEntry(ctx context.Context, entryID string) (*types.PixEntry, *Response, error) {
+	path, err := apiPath("/api/v1/pix/entries/%s", entryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entry types.PixEntry
+	resp, err := s.client.Do(req.WithContext(ctx), &entry, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &entry, resp, nil
+}
+
+// ListRefunds returns the refunds of a received PIX entry.
+func (s *PixService) ListRefunds(ctx context.Context, entryID string) ([]types.PixRefund, *Response, error) {
+	path, err := apiPath("/api/v1/pix/entries/%s/refunds", entryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refunds []types.PixRefund
+	resp, err := s.client.Do(req.WithContext(ctx), &refunds, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return refunds, resp, nil
+}
+
+// Refund returns input.Amount of a received PIX entry to the payer. Several partial refunds are allowed up to the
+// amount of the entry: the refunded total is loaded from ListRefunds and concurrent refunds of the same entry are
+// serialized, so refunds exceeding it fail with ErrValidation without calling Stone.
+//
+// When the outcome of a refund is unknown, retry it with the same input: its IdempotencyKey, generated on the first
+// call when empty, makes Stone return the original refund instead of refunding twice. Such retries skip the local
+// limit check, since the refunded total may already include the refund being retried.
+func (s *PixService) Refund(ctx context.Context, entryID string, input *types.PixRefundInput) (*types.PixRefund, *Response, error) {
+	var errs validationErrors
+	if input.Amount <= 0 {
+		errs.add("must be greater than 0", "amount")
+	}
+	switch input.Reason {
+	case types.RefundReasonBankError, types.RefundReasonFraud, types.RefundReasonRequested, types.RefundReasonCashout:
+	default:
+		errs.add(fmt.Sprintf("invalid reason code %q", input.Reason), "reason")
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+	path, err := apiPath("/api/v1/pix/entries/%s/refunds", entryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	guard := s.acquireRefundGuard(entryID)
+	defer s.releaseRefundGuard(entryID, guard)
+	guard.m.Lock()
+	defer guard.m.Unlock()
+
+	replay := s.refundUnresolved(entryID, input.IdempotencyKey)
+	if !replay {
+		if !guard.loaded {
+			if resp, err := s.loadRefundGuard(ctx, entryID, guard); err != nil {
+				return nil, resp, err
+			}
+		}
+		if available := guard.original - guard.refunded; input.Amount > available {
+			errs.add(fmt.Sprintf("exceeds the refundable amount of %s", available), "amount")
+			return nil, nil, errs.err()
+		}
+	}
+
+	var refund types.PixRefund
+	resp, err := s.client.postIdempotent(ctx, path, input, &input.IdempotencyKey, &refund)
+	if err != nil {
+		// The outcome may be unknown, reload the refunded total on the next refund.
+		guard.loaded = false
+		var errResp *ErrorResponse
+		unknown := !errors.As(err, &errResp) || errResp.Response.StatusCode >= http.StatusInternalServerError
+		s.setRefundUnresolved(entryID, input.IdempotencyKey, unknown)
+		return nil, resp, err
+	}
+	s.setRefundUnresolved(entryID, input.IdempotencyKey, false)
+
+	switch {
+	case replay:
+		// The loaded total may or may not include the replayed refund.
+		guard.loaded = false
+	case refund.Status != types.PixStatusFailed:
+		guard.refunded += input.Amount
+	}
+	return &refund, resp, nil
+}
+
+// refundUnresolved reports whether a refund of entryID sent with idempotencyKey failed with an unknown outcome.
+func (s *PixService) refundUnresolved(entryID, idempotencyKey string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return idempotencyKey != "" && s.unresolvedRefunds[entryID+" "+idempotencyKey]
+}
+
+// setRefundUnresolved records whether the outcome of the refund of entryID sent with idempotencyKey is unknown. Keys
+// are kept until a retry resolves them.
+func (s *PixService) setRefundUnresolved(entryID, idempotencyKey string, unresolved bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	key := entryID + " " + idempotencyKey
+	if !unresolved {
+		delete(s.unresolvedRefunds, key)
+		return
+	}
+	if s.unresolvedRefunds == nil {
+		s.unresolvedRefunds = make(map[string]bool)
+	}
+	s.unresolvedRefunds[key] = true
+}
+
+// acquireRefundGuard returns the guard of entryID, creating it when no refund of the entry is in progress.
+func (s *PixService) acquireRefundGuard(entryID string) *refundGuard {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.refunds == nil {
+		s.refunds = make(map[string]*refundGuard)
+	}
+	guard, ok := s.refunds[entryID]
+	if !ok {
+		guard = &refundGuard{}
+		s.refunds[entryID] = guard
+	}
+	guard.users++
+	return guard
+}
+
+// releaseRefundGuard drops the guard of entryID once its last refund finished, so that the map only holds entries
+// with refunds in progress.
+func (s *PixService) releaseRefundGuard(entryID string, guard *refundGuard) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	guard.users--
+	if guard.users == 0 {
+		delete(s.refunds, entryID)
+	}
+}
+
+func (s *PixService) loadRefundGuard(ctx context.Context, entryID string, guard *refundGuard) (*Response, error) {
+	entry, resp, err := s.GetEntry(ctx, entryID)
+	if err != nil {
+		return resp, err
+	}
+	refunds, resp, err := s.ListRefunds(ctx, entryID)
+	if err != nil {
+		return resp, err
+	}
+
+	guard.original, guard.refunded = entry.Amount, 0
+	for _, refund := range refunds {
+		if refund.Status != types.PixStatusFailed {
+			guard.refunded += refund.Amount
+		}
+	}
+	guard.loaded = true
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestPixRefund(t *testing.T) {
+	var m sync.Mutex
+	var posted []types.Money
+	keys := make(map[string]bool)
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/v1/pix/entries/pix-1":
+			_, _ = w.Write([]byte(`{"id":"pix-1","amount":10000,"status":"CONFIRMED"}`))
+		case r.URL.Path == "/api/v1/pix/entries/pix-1/refunds" && r.Method == http.MethodGet:
+			refunds := `{"id":"r-0","amount":2000,"status":"CONFIRMED"},{"id":"r-x","amount":5000,"status":"FAILED"}`
+			for i, amount := range posted {
+				refunds += fmt.Sprintf(`,{"id":"r-%d","amount":%d,"status":"PROCESSING"}`, i+1, amount)
+			}
+			_, _ = w.Write([]byte("[" + refunds + "]"))
+		case r.URL.Path == "/api/v1/pix/entries/pix-1/refunds":
+			var input struct {
+				Amount types.Money `json:"amount"`
+				Reason string      `json:"reason"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&input)
+			key := r.Header.Get(idempotencyHeader)
+			if key == "" || keys[key] {
+				t.Errorf("idempotency key %q missing or reused", key)
+			}
+			keys[key] = true
+			posted = append(posted, input.Amount)
+			_, _ = fmt.Fprintf(w, `{"id":"r-%d","amount":%d,"reason":%q,"status":"PROCESSING"}`, len(posted), input.Amount, input.Reason)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	testCases := []struct {
+		Name          string
+		Amount        types.Money
+		Reason        string
+		ExpectedError bool
+	}{
+		{Name: "Should refund partially", Amount: 3000, Reason: types.RefundReasonRequested},
+		{Name: "Should refund again up to the remaining amount", Amount: 5000, Reason: types.RefundReasonBankError},
+		{Name: "Should reject refund beyond the original amount", Amount: 1, Reason: types.RefundReasonRequested, ExpectedError: true},
+		{Name: "Should reject unknown reason", Amount: 1, Reason: "XX01", ExpectedError: true},
+		{Name: "Should reject non-positive amount", Amount: 0, Reason: types.RefundReasonRequested, ExpectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			refund, _, err := c.Pix.Refund(ctx, "pix-1", &types.PixRefundInput{Amount: testCase.Amount, Reason: testCase.Reason})
+
+			// Asserts
+			if testCase.ExpectedError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Refund() error = %v", err)
+			}
+			if refund.Amount != testCase.Amount || refund.Reason != testCase.Reason {
+				t.Errorf("unexpected refund %+v", refund)
+			}
+		})
+	}
+
+	if len(posted) != 2 {
+		t.Errorf("posted refunds %v, expected 2", posted)
+	}
+
+	refunds, _, err := c.Pix.ListRefunds(ctx, "pix-1")
+	if err != nil || len(refunds) != 4 {
+		t.Errorf("ListRefunds() = %v, %v", refunds, err)
+	}
+}
+
+func TestPixRefundConcurrent(t *testing.T) {
+	var m sync.Mutex
+	var refunded types.Money
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/pix/entries/pix-1":
+			_, _ = w.Write([]byte(`{"id":"pix-1","amount":1000}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			m.Lock()
+			refunded += 100
+			m.Unlock()
+			_, _ = w.Write([]byte(`{"status":"PROCESSING"}`))
+		}
+	})
+
+	// Twenty concurrent refunds of R$ 1,00 over an entry of R$ 10,00: exactly ten may go through.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = c.Pix.Refund(context.Background(), "pix-1", &types.PixRefundInput{Amount: 100, Reason: types.RefundReasonRequested})
+		}()
+	}
+	wg.Wait()
+
+	if refunded != 1000 {
+		t.Errorf("refunded %s, expected R$ 10,00", refunded)
+	}
+	if len(c.Pix.refunds) != 0 {
+		t.Errorf("%d refund guards left after the refunds finished", len(c.Pix.refunds))
+	}
+}
+
+func TestPixRefundRetry(t *testing.T) {
+	testCases := []struct {
+		Name string
+		// FirstAttempt answers the first POST, after the server processed it when Processed is set.
+		FirstAttempt func(w http.ResponseWriter)
+		Processed    bool
+	}{
+		{
+			Name:         "Should retry refund rejected with 400",
+			FirstAttempt: func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadRequest) },
+		},
+		{
+			Name:         "Should replay refund processed by Stone when the response is lost",
+			FirstAttempt: func(http.ResponseWriter) { panic(http.ErrAbortHandler) },
+			Processed:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			var m sync.Mutex
+			var keys []string
+			refunds := make(map[string]string)
+
+			c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				m.Lock()
+				defer m.Unlock()
+
+				switch {
+				case r.URL.Path == "/api/v1/pix/entries/pix-1":
+					_, _ = w.Write([]byte(`{"id":"pix-1","amount":1000}`))
+				case r.Method == http.MethodGet:
+					var list []string
+					for _, refund := range refunds {
+						list = append(list, refund)
+					}
+					_, _ = w.Write([]byte("[" + strings.Join(list, ",") + "]"))
+				default:
+					key := r.Header.Get(idempotencyHeader)
+					keys = append(keys, key)
+					if refund, ok := refunds[key]; ok {
+						_, _ = w.Write([]byte(refund))
+						return
+					}
+					refund := `{"id":"r-1","amount":1000,"status":"PROCESSING"}`
+					if len(keys) == 1 {
+						if testCase.Processed {
+							refunds[key] = refund
+						}
+						testCase.FirstAttempt(w)
+						return
+					}
+					refunds[key] = refund
+					_, _ = w.Write([]byte(refund))
+				}
+			})
+			input := &types.PixRefundInput{Amount: 1000, Reason: types.RefundReasonRequested}
+
+			// Act
+			if _, _, err := c.Pix.Refund(context.Background(), "pix-1", input); err == nil {
+				t.Fatal("first Refund() succeeded, expected error")
+			}
+			refund, _, err := c.Pix.Refund(context.Background(), "pix-1", input)
+
+			// Asserts
+			if err != nil {
+				t.Fatalf("retried Refund() error = %v", err)
+			}
+			if refund.ID != "r-1" || refund.Amount != 1000 {
+				t.Errorf("retried Refund() = %+v, expected the original refund", refund)
+			}
+			if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] || input.IdempotencyKey != keys[0] {
+				t.Errorf("idempotency keys %v, expected the same generated key twice", keys)
+			}
+			if len(refunds) != 1 {
+				t.Errorf("%d refunds processed, expected 1", len(refunds))
+			}
+			if len(c.Pix.unresolvedRefunds) != 0 {
+				t.Errorf("%d unresolved refunds left after the retry", len(c.Pix.unresolvedRefunds))
+			}
+			if _, _, err := c.Pix.Refund(context.Background(), "pix-1", &types.PixRefundInput{Amount: 1, Reason: types.RefundReasonRequested}); !errors.Is(err, ErrValidation) {
+				t.Errorf("Refund() beyond the refunded total error = %v, expected ErrValidation", err)
+			}
+		})
+	}
+}
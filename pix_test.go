@@ -0,0 +1,157 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestPixPayments(t *testing.T) {
+	var gotPath, gotKey string
+	var gotBody map[string]interface{}
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"key":"+5511999999999","key_type":"phone","end_to_end_id":"E1","account":{"institution_code":"18236120","branch_code":"0001","account_code":"123"},"owner":{"name":"Fulano","document":"***.982.247-**"}}`))
+			return
+		}
+		gotPath, gotKey = r.URL.Path, r.Header.Get(idempotencyHeader)
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"id":"pix-1","end_to_end_id":"E1","amount":1000,"status":"CONFIRMED"}`))
+	})
+	ctx := context.Background()
+
+	lookup, _, err := c.Pix.LookupKey(ctx, "acc-1", "+5511999999999")
+	if err != nil || lookup.EndToEndID != "E1" || lookup.Owner.Name != "Fulano" {
+		t.Fatalf("LookupKey() = %+v, %v", lookup, err)
+	}
+
+	manual := types.Beneficiary{
+		Account: types.BankAccount{ISPB: "18236120", BranchCode: "0001", AccountCode: "123", AccountType: types.AccountTypePayment},
+		Entity:  types.Entity{Name: "Fulano", Document: "529.982.247-25"},
+	}
+
+	testCases := []struct {
+		Name          string
+		Pay           func() (*types.PixEntry, string, error)
+		ExpectedPath  string
+		ExpectedField string
+		ExpectedError bool
+	}{
+		{
+			Name: "Should pay key",
+			Pay: func() (*types.PixEntry, string, error) {
+				input := &types.PixKeyPaymentInput{AccountID: "acc-1", Amount: 1000, Key: lookup.Key, EndToEndID: lookup.EndToEndID}
+				entry, _, err := c.Pix.PayKey(ctx, input)
+				return entry, input.IdempotencyKey, err
+			},
+			ExpectedPath:  "/api/v1/pix/outbound_pix_payments",
+			ExpectedField: "end_to_end_id",
+		},
+		{
+			Name: "Should pay manual account",
+			Pay: func() (*types.PixEntry, string, error) {
+				input := &types.PixManualPaymentInput{AccountID: "acc-1", Amount: 1000, Beneficiary: manual}
+				entry, _, err := c.Pix.PayManual(ctx, input)
+				return entry, input.IdempotencyKey, err
+			},
+			ExpectedPath:  "/api/v1/pix/outbound_pix_payments",
+			ExpectedField: "target",
+		},
+		{
+			Name: "Should pay BR Code",
+			Pay: func() (*types.PixEntry, string, error) {
+				input := &types.PixBRCodePaymentInput{AccountID: "acc-1", BRCode: "00020126...6304ABCD", IdempotencyKey: "brcode-1"}
+				entry, _, err := c.Pix.PayBRCode(ctx, input)
+				return entry, input.IdempotencyKey, err
+			},
+			ExpectedPath:  "/api/v1/pix/outbound_pix_payments/brcode",
+			ExpectedField: "brcode",
+		},
+		{
+			Name: "Should require key lookup",
+			Pay: func() (*types.PixEntry, string, error) {
+				entry, _, err := c.Pix.PayKey(ctx, &types.PixKeyPaymentInput{AccountID: "acc-1", Amount: 1000, Key: lookup.Key})
+				return entry, "", err
+			},
+			ExpectedError: true,
+		},
+		{
+			Name: "Should require ISPB on manual payments",
+			Pay: func() (*types.PixEntry, string, error) {
+				b := manual
+				b.Account.ISPB, b.Account.BankCode = "", "197"
+				entry, _, err := c.Pix.PayManual(ctx, &types.PixManualPaymentInput{AccountID: "acc-1", Amount: 1000, Beneficiary: b})
+				return entry, "", err
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			gotPath, gotKey, gotBody = "", "", nil
+
+			// Act
+			entry, key, err := testCase.Pay()
+
+			// Asserts
+			if testCase.ExpectedError {
+				if !errors.Is(err, ErrValidation) || gotPath != "" {
+					t.Errorf("expected local ErrValidation, got %v after calling %q", err, gotPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if gotPath != testCase.ExpectedPath || gotKey == "" || gotKey != key {
+				t.Errorf("path = %s, idempotency key = %q, input key = %q", gotPath, gotKey, key)
+			}
+			if _, ok := gotBody[testCase.ExpectedField]; !ok {
+				t.Errorf("body %v misses %s", gotBody, testCase.ExpectedField)
+			}
+			if entry.EndToEndID != "E1" || !entry.Final() {
+				t.Errorf("unexpected entry %+v", entry)
+			}
+		})
+	}
+
+	t.Run("Should infer beneficiary document type", func(t *testing.T) {
+		// Act
+		_, _, err := c.Pix.PayManual(ctx, &types.PixManualPaymentInput{AccountID: "acc-1", Amount: 1000, Beneficiary: manual})
+
+		// Asserts
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		target, _ := gotBody["target"].(map[string]interface{})
+		entity, _ := target["entity"].(map[string]interface{})
+		if entity["document_type"] != types.DocumentTypeCPF {
+			t.Errorf("entity %v, expected document_type %q", entity, types.DocumentTypeCPF)
+		}
+	})
+}
+
+func TestPixAwaitPayment(t *testing.T) {
+	polls := 0
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := types.PixStatusProcessing
+		if polls == 3 {
+			status = types.PixStatusConfirmed
+		}
+		_, _ = w.Write([]byte(`{"id":"pix-1","end_to_end_id":"E1","status":"` + status + `"}`))
+	})
+
+	entry, err := c.Pix.AwaitPayment(context.Background(), "pix-1", time.Millisecond)
+	if err != nil || entry.Status != types.PixStatusConfirmed || polls != 3 {
+		t.Errorf("AwaitPayment() = %+v, %v after %d polls", entry, err, polls)
+	}
+}
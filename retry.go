@@ -0,0 +1,160 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const idempotencyHeader = "x-stone-idempotency-key"
+
+// RetryPolicy configures how Client.Do retries failed requests.
+//
+// Transport errors, 429 and 5xx responses are retried. Idempotent methods are always eligible, while POST and PATCH
+// are only retried when the request carries an idempotency key (see Client.AddIdempotencyHeader).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the backoff used before the first retry. It doubles on every following retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff. Zero means no cap.
+	MaxDelay time.Duration
+
+	// MaxRetryAfter caps how long a Retry-After header may make the client wait. When the server asks for a longer
+	// wait the response is returned to the caller instead. Zero means no cap.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns a conservative policy suitable for most callers.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   3,
+		BaseDelay:     200 * time.Millisecond,
+		MaxDelay:      5 * time.Second,
+		MaxRetryAfter: 30 * time.Second,
+	}
+}
+
+// WithRetryPolicy enables automatic retries in Client.Do.
+func WithRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+// backoff returns a full-jitter exponential delay for the given retry (starting at 1).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.maxBackoff(retry)
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d) + 1
+}
+
+// maxBackoff returns the exponential delay for the given retry before jitter. Doubling stops before overflowing.
+func (p *RetryPolicy) maxBackoff(retry int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay) && d <= math.MaxInt64/2; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+func isRetryableMethod(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost, http.MethodPatch:
+		return req.Header.Get(idempotencyHeader) != ""
+	}
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// retryAfter parses the Retry-After header, which may be either delay-seconds or an HTTP-date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rewindableBody makes sure the request body can be replayed between attempts.
+func rewindableBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	_ = req.Body.Close()
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// sleepContext waits for d or until the request context is done.
+func sleepContext(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return req.Context().Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
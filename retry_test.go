@@ -0,0 +1,179 @@
+package openbank
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRetries(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Method           string
+		IdempotencyKey   string
+		FailWith         int
+		Failures         int32
+		ExpectedAttempts int32
+		ExpectedError    bool
+	}{
+		{
+			Name:             "Should retry GET on server error",
+			Method:           http.MethodGet,
+			FailWith:         http.StatusServiceUnavailable,
+			Failures:         2,
+			ExpectedAttempts: 3,
+		},
+		{
+			Name:             "Should retry GET on rate limit",
+			Method:           http.MethodGet,
+			FailWith:         http.StatusTooManyRequests,
+			Failures:         1,
+			ExpectedAttempts: 2,
+		},
+		{
+			Name:             "Should give up after max attempts",
+			Method:           http.MethodGet,
+			FailWith:         http.StatusBadGateway,
+			Failures:         5,
+			ExpectedAttempts: 3,
+			ExpectedError:    true,
+		},
+		{
+			Name:             "Should not retry POST without idempotency key",
+			Method:           http.MethodPost,
+			FailWith:         http.StatusServiceUnavailable,
+			Failures:         1,
+			ExpectedAttempts: 1,
+			ExpectedError:    true,
+		},
+		{
+			Name:             "Should retry POST with idempotency key",
+			Method:           http.MethodPost,
+			IdempotencyKey:   "key-1",
+			FailWith:         http.StatusServiceUnavailable,
+			Failures:         1,
+			ExpectedAttempts: 2,
+		},
+		{
+			Name:             "Should not retry client errors",
+			Method:           http.MethodGet,
+			FailWith:         http.StatusBadRequest,
+			Failures:         1,
+			ExpectedAttempts: 1,
+			ExpectedError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != `{"amount":100}`+"\n" {
+					t.Errorf("attempt %d: body = %q, expected request body to be rewound", n, body)
+				}
+				if n <= testCase.Failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(testCase.FailWith)
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"field":"ok"}]}`))
+			}))
+			defer server.Close()
+
+			baseURL, _ := SetBaseURL(server.URL)
+			c, _ := NewClient(baseURL, WithRetryPolicy(RetryPolicy{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    5 * time.Millisecond,
+			}))
+
+			var body interface{}
+			if testCase.Method == http.MethodPost {
+				body = map[string]int{"amount": 100}
+			}
+			req, _ := c.NewAPIRequest(testCase.Method, "/retry", body)
+			_ = c.AddIdempotencyHeader(req, testCase.IdempotencyKey)
+
+			// Act
+			_, err := c.Do(req, new(responseBody), new(responseBody))
+
+			// Asserts
+			if testCase.ExpectedError && err == nil {
+				t.Error("expected err got nil")
+			}
+			if !testCase.ExpectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got := attempts.Load(); got != testCase.ExpectedAttempts {
+				t.Errorf("attempts = %d, expected %d", got, testCase.ExpectedAttempts)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Policy   RetryPolicy
+		Retry    int
+		Expected time.Duration
+	}{
+		{Name: "Should start at base delay", Policy: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, Retry: 1, Expected: 100 * time.Millisecond},
+		{Name: "Should double on every retry", Policy: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, Retry: 3, Expected: 400 * time.Millisecond},
+		{Name: "Should cap at max delay", Policy: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, Retry: 10, Expected: time.Second},
+		{Name: "Should grow without cap when max delay is zero", Policy: RetryPolicy{BaseDelay: 100 * time.Millisecond}, Retry: 5, Expected: 1600 * time.Millisecond},
+		{Name: "Should stop doubling before overflowing", Policy: RetryPolicy{BaseDelay: 100 * time.Millisecond}, Retry: 100, Expected: 100 * time.Millisecond << 36},
+		{Name: "Should not wait without base delay", Policy: RetryPolicy{MaxDelay: time.Second}, Retry: 3, Expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			got := testCase.Policy.maxBackoff(testCase.Retry)
+			jittered := testCase.Policy.backoff(testCase.Retry)
+
+			// Asserts
+			if got != testCase.Expected {
+				t.Errorf("maxBackoff(%d) = %v, expected %v", testCase.Retry, got, testCase.Expected)
+			}
+			if jittered < 0 || jittered > got || (got > 0 && jittered == 0) {
+				t.Errorf("backoff(%d) = %v, expected within (0, %v]", testCase.Retry, jittered, got)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Name     string
+		Header   string
+		Expected time.Duration
+		Ok       bool
+	}{
+		{Name: "Should parse delay seconds", Header: "3", Expected: 3 * time.Second, Ok: true},
+		{Name: "Should parse HTTP date", Header: now.Add(10 * time.Second).Format(http.TimeFormat), Expected: 10 * time.Second, Ok: true},
+		{Name: "Should ignore missing header", Header: "", Ok: false},
+		{Name: "Should ignore invalid header", Header: "soon", Ok: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if testCase.Header != "" {
+				resp.Header.Set("Retry-After", testCase.Header)
+			}
+
+			d, ok := retryAfter(resp, now)
+			if ok != testCase.Ok || d != testCase.Expected {
+				t.Errorf("retryAfter(%q) = %v, %v, expected %v, %v", testCase.Header, d, ok, testCase.Expected, testCase.Ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package openbank
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// WithSigner makes the client sign its client assertions with signer instead of an in-memory PEM key. Use it with
+// an HSM or KMS backed crypto.Signer so the private key never leaves it.
+func WithSigner(signer crypto.Signer) ClientOpt {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithDecrypter makes the client decrypt webhooks with decrypter, an RSA crypto.Decrypter such as an HSM or KMS key.
+// It is only needed when the key given to WithSigner can not decrypt: an RSA signer that also implements
+// crypto.Decrypter is used for webhooks as well.
+func WithDecrypter(decrypter crypto.Decrypter) ClientOpt {
+	return func(c *Client) {
+		c.decrypter = decrypter
+	}
+}
+
+// setupDecryption picks the key that decrypts webhooks. Clients without one can still call the API, but ParseWebhook
+// fails.
+func (c *Client) setupDecryption() error {
+	if c.decrypter == nil && c.privateKey != nil {
+		c.decrypter = c.privateKey
+	}
+	if c.decrypter == nil {
+		if d, ok := c.signer.(crypto.Decrypter); ok {
+			if _, rsaKey := d.Public().(*rsa.PublicKey); rsaKey {
+				c.decrypter = d
+			}
+		}
+	}
+	if c.decrypter != nil {
+		if _, ok := c.decrypter.Public().(*rsa.PublicKey); !ok {
+			return fmt.Errorf("webhook decrypter must hold an RSA key, got %T", c.decrypter.Public())
+		}
+	}
+	return nil
+}
+
+// webhookDecryptionKey returns the key given to go-jose to decrypt webhooks.
+func (c *Client) webhookDecryptionKey() interface{} {
+	if key, ok := c.decrypter.(*rsa.PrivateKey); ok {
+		return key
+	}
+	return &opaqueDecrypter{decrypter: c.decrypter}
+}
+
+// opaqueDecrypter decrypts the content encryption key of a JWE with a crypto.Decrypter, so the private key can stay
+// in an HSM or KMS.
+type opaqueDecrypter struct {
+	decrypter crypto.Decrypter
+}
+
+func (d *opaqueDecrypter) DecryptKey(encryptedKey []byte, header jose.Header) ([]byte, error) {
+	var opts *rsa.OAEPOptions
+	switch jose.KeyAlgorithm(header.Algorithm) {
+	case jose.RSA_OAEP:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA1}
+	case jose.RSA_OAEP_256:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA256}
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", header.Algorithm)
+	}
+	return d.decrypter.Decrypt(rand.Reader, encryptedKey, opts)
+}
+
+// localSigner hides the concrete key type behind crypto.Signer, behaving like a remote signer would.
+type localSigner struct {
+	key crypto.Signer
+}
+
+// NewLocalSigner returns a software crypto.Signer backed by a PEM encoded private key (PKCS #1, PKCS #8 or SEC 1).
+// It is meant for tests and local development of code that uses WithSigner.
+func NewLocalSigner(pemData []byte) (crypto.Signer, error) {
+	key, err := parsePEMPrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	return &localSigner{key: key}, nil
+}
+
+func parsePEMPrivateKey(pemData []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("invalid private key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key can not sign")
+	}
+	return signer, nil
+}
+
+func (s *localSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *localSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+// Decrypt decrypts with RSA keys, like KMS keys allowed to both sign and decrypt.
+func (s *localSigner) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	d, ok := s.key.(crypto.Decrypter)
+	if !ok {
+		return nil, errors.New("private key can not decrypt")
+	}
+	return d.Decrypt(rand, msg, opts)
+}
@@ -0,0 +1,69 @@
+package openbank
+
+import (
+	"context"
+	"crypto"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// countingSigner records how many times the wrapped signer was used.
+type countingSigner struct {
+	crypto.Signer
+	calls atomic.Int32
+}
+
+func (s *countingSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.calls.Add(1)
+	return s.Signer.Sign(rand, digest, opts)
+}
+
+func TestGenerateTokenWithSigner(t *testing.T) {
+	local, err := NewLocalSigner(testPEMPrivateKey(t))
+	if err != nil {
+		t.Fatalf("NewLocalSigner() error = %v", err)
+	}
+	signer := &countingSigner{Signer: local}
+
+	c, _ := NewClient(WithClientID("client-id"), WithSigner(signer))
+
+	tokenString, err := c.generateToken(c.authClaims())
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return signer.Public(), nil
+	})
+	if err != nil || !token.Valid {
+		t.Fatalf("invalid client assertion: %v", err)
+	}
+	if token.Method.Alg() != "RS256" {
+		t.Errorf("alg = %s, expected RS256", token.Method.Alg())
+	}
+	if got := signer.calls.Load(); got != 1 {
+		t.Errorf("signer calls = %d, expected 1", got)
+	}
+}
+
+func TestAuthenticateWithSigner(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	signer, _ := NewLocalSigner(testPEMPrivateKey(t))
+
+	accountURL, _ := SetAccountURL(s.URL)
+	c, _ := NewClient(WithClientID("client-id"), WithSigner(signer), accountURL)
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+}
+
+func TestNewLocalSignerRejectsInvalidPEM(t *testing.T) {
+	if _, err := NewLocalSigner([]byte("not a key")); err == nil {
+		t.Error("expected err got nil")
+	}
+}
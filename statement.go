@@ -0,0 +1,105 @@
+package openbank
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// StatementsService handles account statements.
+type StatementsService struct {
+	client *Client
+}
+
+// Page returns a single page of the account statement. Use the returned cursor in filter.After to fetch the next one.
+func (s *StatementsService) Page(ctx context.Context, accountID string, filter types.StatementFilter) (*types.StatementPage, *Response, error) {
+	path, err := apiPath("/api/v1/accounts/%s/statement", accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if q := statementQuery(filter).Encode(); q != "" {
+		path += "?" + q
+	}
+
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var page types.StatementPage
+	resp, err := s.client.Do(req.WithContext(ctx), &page, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if filter.Operation != "" {
+		entries := page.Data[:0]
+		for _, e := range page.Data {
+			if e.Operation == filter.Operation {
+				entries = append(entries, e)
+			}
+		}
+		page.Data = entries
+	}
+
+	return &page, resp, nil
+}
+
+// Pages iterates lazily over the statement pages, following the cursor until the last page. Iteration stops at the
+// first error, which is yielded with a nil page.
+func (s *StatementsService) Pages(ctx context.Context, accountID string, filter types.StatementFilter) iter.Seq2[*types.StatementPage, error] {
+	return paginate(filter.After, func(after string) ([]*types.StatementPage, string, error) {
+		filter.After = after
+		page, _, err := s.Page(ctx, accountID, filter)
+		if err != nil {
+			return nil, "", err
+		}
+		return []*types.StatementPage{page}, page.Cursor.After, nil
+	})
+}
+
+// List iterates lazily over the statement entries, fetching pages as needed. Iteration stops at the first error.
+func (s *StatementsService) List(ctx context.Context, accountID string, filter types.StatementFilter) iter.Seq2[types.Entry, error] {
+	return func(yield func(types.Entry, error) bool) {
+		for page, err := range s.Pages(ctx, accountID, filter) {
+			if err != nil {
+				yield(types.Entry{}, err)
+				return
+			}
+			for _, entry := range page.Data {
+				if !yield(entry, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func statementQuery(filter types.StatementFilter) url.Values {
+	q := url.Values{}
+	if !filter.Start.IsZero() {
+		q.Set("start_datetime", filter.Start.Format(time.RFC3339))
+	}
+	if !filter.End.IsZero() {
+		q.Set("end_datetime", filter.End.Format(time.RFC3339))
+	}
+	if len(filter.Types) > 0 {
+		q.Set("type", strings.Join(filter.Types, ","))
+	}
+	if filter.Operation != "" {
+		q.Set("operation", string(filter.Operation))
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.After != "" {
+		q.Set("after", filter.After)
+	}
+	return q
+}
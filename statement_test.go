@@ -0,0 +1,118 @@
+package openbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func newStatementAPI(t *testing.T, pages int) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/api/v1/accounts/acc-1/statement" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("start_datetime"); got != "2025-01-01T00:00:00Z" {
+			t.Errorf("start_datetime = %q", got)
+		}
+
+		page := 0
+		fmt.Sscanf(r.URL.Query().Get("after"), "page-%d", &page)
+		if page >= pages {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		after := ""
+		if page+1 < pages {
+			after = fmt.Sprintf("page-%d", page+1)
+		}
+		fmt.Fprintf(w, `{"cursor":{"after":%q,"limit":2},"data":[
+			{"id":"e%d-1","operation":"credit","amount":100},
+			{"id":"e%d-2","operation":"debit","amount":50}
+		]}`, after, page, page)
+	})
+	return c, &requests
+}
+
+func TestStatementsList(t *testing.T) {
+	c, requests := newStatementAPI(t, 3)
+	filter := types.StatementFilter{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	var ids []string
+	for entry, err := range c.Statements.List(context.Background(), "acc-1", filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	if len(ids) != 6 || ids[0] != "e0-1" || ids[5] != "e2-2" {
+		t.Errorf("entries = %v", ids)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, expected 3", got)
+	}
+}
+
+func TestStatementsListIsLazy(t *testing.T) {
+	c, requests := newStatementAPI(t, 3)
+	filter := types.StatementFilter{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	for range c.Statements.List(context.Background(), "acc-1", filter) {
+		break
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, expected 1", got)
+	}
+}
+
+func TestStatementsListFiltersOperation(t *testing.T) {
+	c, _ := newStatementAPI(t, 2)
+	filter := types.StatementFilter{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Operation: types.EntryDebit}
+
+	count := 0
+	for entry, err := range c.Statements.List(context.Background(), "acc-1", filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if entry.Operation != types.EntryDebit {
+			t.Errorf("unexpected %s entry %s", entry.Operation, entry.ID)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("entries = %d, expected 2", count)
+	}
+}
+
+func TestStatementsPagesResume(t *testing.T) {
+	c, _ := newStatementAPI(t, 3)
+	filter := types.StatementFilter{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), After: "page-2"}
+
+	page, _, err := c.Statements.Page(context.Background(), "acc-1", filter)
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if page.Cursor.After != "" || page.Data[0].ID != "e2-1" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	filter.After = "page-9"
+	for _, err := range c.Statements.List(context.Background(), "acc-1", filter) {
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	}
+}
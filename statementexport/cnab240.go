@@ -0,0 +1,284 @@
+package statementexport
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const cnabRecordSize = 240
+
+// CNAB 240 layout versions written in the file and batch headers.
+const (
+	cnabFileLayout  = "089"
+	cnabBatchLayout = "033"
+)
+
+// WriteCNAB240 writes the statement as a FEBRABAN CNAB 240 extrato file for bank reconciliation (service 04), with a
+// single batch and one segment E record per entry. Records are 240 characters long and end with CRLF.
+//
+// The last digit of the account code is written as its check digit.
+func WriteCNAB240(w io.Writer, s *Statement) error {
+	bw := bufio.NewWriter(w)
+	entries := s.sortedEntries()
+	opening, closing := s.balances()
+	generated := s.generatedAt().In(brt)
+
+	nsa := s.FileSequence
+	if nsa <= 0 {
+		nsa = 1
+	}
+
+	records := []cnabRecord{cnabFileHeader(s, generated, nsa), cnabBatchHeader(s, opening, nsa)}
+
+	var debits, credits types.Money
+	for i, e := range entries {
+		if e.Operation == types.EntryDebit {
+			debits += e.Amount.Abs()
+		} else {
+			credits += e.Amount.Abs()
+		}
+		records = append(records, cnabSegmentE(s, e, i+1))
+	}
+
+	records = append(records,
+		cnabBatchTrailer(s, closing, len(entries)+2, debits, credits),
+		cnabFileTrailer(len(entries)+4),
+	)
+
+	for _, r := range records {
+		if _, err := bw.Write(r[:]); err != nil {
+			return err
+		}
+		if _, err := bw.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// cnabAccount fills positions 18 to 102, shared by every record but the file trailer: company registration, agreement,
+// branch, account and company name.
+func cnabAccount(r *cnabRecord, s *Statement) {
+	// Alphanumeric CNPJs keep their letters, in the same zero padded field.
+	documentType := 2
+	if s.Account.OwnerDocument.Type() == types.DocumentTypeCPF {
+		documentType = 1
+	}
+	number, digit := splitCheckDigit(s.Account.AccountCode)
+
+	r.num(18, 1, int64(documentType))
+	r.digits(19, 14, s.Account.OwnerDocument.Normalized())
+	r.alpha(33, 20, "")
+	r.digits(53, 5, digitsOnly(s.Account.BranchCode))
+	r.alpha(58, 1, "")
+	r.digits(59, 12, number)
+	r.alpha(71, 1, digit)
+	r.alpha(72, 1, "")
+	r.alpha(73, 30, s.Account.OwnerName)
+}
+
+func cnabFileHeader(s *Statement, generated time.Time, nsa int) cnabRecord {
+	r := newCNABRecord()
+	r.alpha(1, 3, StoneBankCode)
+	r.alpha(4, 4, "0000")
+	r.alpha(8, 1, "0")
+	cnabAccount(&r, s)
+	r.alpha(103, 30, stoneBankName)
+	r.alpha(143, 1, "2")
+	r.alpha(144, 8, generated.Format("02012006"))
+	r.alpha(152, 6, generated.Format("150405"))
+	r.num(158, 6, int64(nsa))
+	r.alpha(164, 3, cnabFileLayout)
+	r.num(167, 5, 0)
+	return r
+}
+
+func cnabBatchHeader(s *Statement, opening types.Money, nsa int) cnabRecord {
+	r := newCNABRecord()
+	r.alpha(1, 3, StoneBankCode)
+	r.alpha(4, 4, "0001")
+	r.alpha(8, 1, "1")
+	r.alpha(9, 1, "E")
+	r.alpha(10, 2, "04")
+	r.alpha(12, 2, "40")
+	r.alpha(14, 3, cnabBatchLayout)
+	cnabAccount(&r, s)
+	r.alpha(143, 8, s.Start.In(brt).Format("02012006"))
+	r.num(151, 18, int64(opening.Abs()))
+	r.alpha(169, 1, cnabBalanceSign(opening))
+	r.alpha(170, 1, "F")
+	r.alpha(171, 3, "BRL")
+	r.num(174, 5, int64(nsa))
+	return r
+}
+
+func cnabSegmentE(s *Statement, e types.Entry, seq int) cnabRecord {
+	date := e.CreatedAt.In(brt).Format("02012006")
+	category := cnabCategory(e)
+
+	r := newCNABRecord()
+	r.alpha(1, 3, StoneBankCode)
+	r.alpha(4, 4, "0001")
+	r.alpha(8, 1, "3")
+	r.num(9, 5, int64(seq))
+	r.alpha(14, 1, "E")
+	cnabAccount(&r, s)
+	r.alpha(109, 3, "DPV")
+	r.alpha(112, 2, "00")
+	r.alpha(134, 1, "N")
+	r.alpha(135, 8, date)
+	r.alpha(143, 8, date)
+	r.num(151, 18, int64(e.Amount.Abs()))
+	r.alpha(169, 1, cnabEntrySign(e))
+	r.alpha(170, 3, category)
+	r.alpha(173, 4, "0"+category)
+	r.alpha(177, 25, entryDescription(e))
+	r.alpha(202, 39, e.ID)
+	return r
+}
+
+func cnabBatchTrailer(s *Statement, closing types.Money, count int, debits, credits types.Money) cnabRecord {
+	r := newCNABRecord()
+	r.alpha(1, 3, StoneBankCode)
+	r.alpha(4, 4, "0001")
+	r.alpha(8, 1, "5")
+	cnabAccount(&r, s)
+	r.alpha(73, 16, "")
+	r.num(89, 18, 0)
+	r.num(107, 18, 0)
+	r.num(125, 18, 0)
+	r.alpha(143, 8, s.End.In(brt).Format("02012006"))
+	r.num(151, 18, int64(closing.Abs()))
+	r.alpha(169, 1, cnabBalanceSign(closing))
+	r.alpha(170, 1, "F")
+	r.num(171, 6, int64(count))
+	r.num(177, 18, int64(debits))
+	r.num(195, 18, int64(credits))
+	return r
+}
+
+func cnabFileTrailer(count int) cnabRecord {
+	r := newCNABRecord()
+	r.alpha(1, 3, StoneBankCode)
+	r.alpha(4, 4, "9999")
+	r.alpha(8, 1, "9")
+	r.num(18, 6, 1)
+	r.num(24, 6, int64(count))
+	r.num(30, 6, 1)
+	return r
+}
+
+func cnabBalanceSign(m types.Money) string {
+	if m.IsNegative() {
+		return "D"
+	}
+	return "C"
+}
+
+func cnabEntrySign(e types.Entry) string {
+	if e.Operation == types.EntryDebit {
+		return "D"
+	}
+	return "C"
+}
+
+// cnabCategory maps the entry to a FEBRABAN statement category: 1xx for debits, 2xx for credits.
+func cnabCategory(e types.Entry) string {
+	debit := e.Operation == types.EntryDebit
+	t := strings.ToLower(e.Type)
+	switch {
+	case strings.Contains(t, "fee"):
+		if debit {
+			return "105"
+		}
+		return "204"
+	case strings.Contains(t, "internal"):
+		if debit {
+			return "117"
+		}
+		return "213"
+	case strings.Contains(t, "pix"), strings.Contains(t, "external"):
+		if debit {
+			return "120"
+		}
+		return "209"
+	case strings.Contains(t, "boleto"), strings.Contains(t, "payment"):
+		if debit {
+			return "112"
+		}
+		return "202"
+	}
+	if debit {
+		return "125"
+	}
+	return "218"
+}
+
+// cnabRecord is a fixed width CNAB 240 record. Positions are 1-based, as in the FEBRABAN layout.
+type cnabRecord [cnabRecordSize]byte
+
+func newCNABRecord() cnabRecord {
+	var r cnabRecord
+	for i := range r {
+		r[i] = ' '
+	}
+	return r
+}
+
+// alpha writes an alphanumeric field: uppercase ASCII, left aligned and padded with spaces.
+func (r *cnabRecord) alpha(pos, size int, value string) {
+	value = cnabText(value)
+	for i := 0; i < size; i++ {
+		c := byte(' ')
+		if i < len(value) {
+			c = value[i]
+		}
+		r[pos-1+i] = c
+	}
+}
+
+// digits writes a numeric field given as a string of digits, right aligned and padded with zeros.
+func (r *cnabRecord) digits(pos, size int, value string) {
+	if len(value) > size {
+		value = value[len(value)-size:]
+	}
+	r.alpha(pos, size, strings.Repeat("0", size-len(value))+value)
+}
+
+func (r *cnabRecord) num(pos, size int, value int64) {
+	r.digits(pos, size, strconv.FormatInt(value, 10))
+}
+
+var cnabAccents = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// cnabText converts s to the uppercase ASCII subset accepted in CNAB files.
+func cnabText(s string) string {
+	s = cnabAccents.Replace(strings.ToUpper(s))
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
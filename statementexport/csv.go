@@ -0,0 +1,37 @@
+package statementexport
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+var csvHeader = []string{"date", "id", "type", "operation", "description", "amount", "balance_after"}
+
+// WriteCSV writes the statement as RFC 4180 CSV, with CRLF line endings and a header row. Amounts are decimal numbers
+// in reais, negative for debits.
+func WriteCSV(w io.Writer, s *Statement) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range s.sortedEntries() {
+		record := []string{
+			e.CreatedAt.In(brt).Format(time.RFC3339),
+			e.ID,
+			e.Type,
+			string(e.Operation),
+			entryDescription(e),
+			signedAmount(e).Decimal(),
+			e.BalanceAfter.Decimal(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,206 @@
+package statementexport
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	openbank "github.com/stone-payments/merchant-go-stone-openbank/v3"
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testStatement() *Statement {
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 3, day, hour, 30, 0, 0, time.UTC)
+	}
+
+	return &Statement{
+		Account: types.Account{
+			ID:            "acc-1",
+			BranchCode:    "0001",
+			AccountCode:   "1234567",
+			OwnerName:     "Padaria São João Ltda",
+			OwnerDocument: "12.345.678/0001-90",
+		},
+		Start: at(1, 3),
+		End:   at(4, 3),
+		// Deliberately out of order: exporters sort entries chronologically.
+		Entries: []types.Entry{
+			{ID: "e-3", Type: "internal_transfer", Operation: types.EntryDebit, Amount: 2500, BalanceBefore: 11000, BalanceAfter: 8500, CreatedAt: at(3, 12)},
+			{ID: "e-1", Type: "pix_incoming", Operation: types.EntryCredit, Amount: 10000, BalanceBefore: 1000, BalanceAfter: 11000, Description: `PIX de "Maria" & cia`, CreatedAt: at(2, 10)},
+			{ID: "e-4", Type: "fee", Operation: types.EntryDebit, Amount: 150, BalanceBefore: 8500, BalanceAfter: 8350, Description: "Tarifa, mensal", CreatedAt: at(3, 18)},
+		},
+	}
+}
+
+func TestExporters(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Golden string
+		Write  func(*bytes.Buffer, *Statement) error
+	}{
+		{Name: "OFX", Golden: "statement.ofx", Write: func(b *bytes.Buffer, s *Statement) error { return WriteOFX(b, s) }},
+		{Name: "CSV", Golden: "statement.csv", Write: func(b *bytes.Buffer, s *Statement) error { return WriteCSV(b, s) }},
+		{Name: "CNAB240", Golden: "statement.cnab240", Write: func(b *bytes.Buffer, s *Statement) error { return WriteCNAB240(b, s) }},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			var buf bytes.Buffer
+			if err := testCase.Write(&buf, testStatement()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Asserts
+			golden := filepath.Join("testdata", testCase.Golden)
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("error reading golden file: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Errorf("output differs from %s:\n%s", golden, buf.String())
+			}
+		})
+	}
+}
+
+func TestCNAB240RecordSize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCNAB240(&buf, testStatement()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 7 {
+		t.Fatalf("records = %d, expected 7", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) != cnabRecordSize {
+			t.Errorf("record %d has %d characters", i+1, len(line))
+		}
+	}
+	if got := lines[2][176:201]; got != "PIX DE \"MARIA\" & CIA     " {
+		t.Errorf("description = %q", got)
+	}
+}
+
+func TestCNAB240CompanyDocument(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Document         types.Document
+		ExpectedType     string
+		ExpectedDocument string
+	}{
+		{Name: "Should write numeric CNPJ", Document: "12.345.678/0001-90", ExpectedType: "2", ExpectedDocument: "12345678000190"},
+		{Name: "Should keep the letters of alphanumeric CNPJ", Document: "12.ABC.345/01DE-35", ExpectedType: "2", ExpectedDocument: "12ABC34501DE35"},
+		{Name: "Should write CPF", Document: "529.982.247-25", ExpectedType: "1", ExpectedDocument: "00052998224725"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			statement := testStatement()
+			statement.Account.OwnerDocument = testCase.Document
+
+			// Act
+			var buf bytes.Buffer
+			if err := WriteCNAB240(&buf, statement); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Asserts
+			header := buf.String()[:cnabRecordSize]
+			if got := header[17:18]; got != testCase.ExpectedType {
+				t.Errorf("document type = %q, expected %q", got, testCase.ExpectedType)
+			}
+			if got := header[18:32]; got != testCase.ExpectedDocument {
+				t.Errorf("document = %q, expected %q", got, testCase.ExpectedDocument)
+			}
+		})
+	}
+}
+
+func TestCNABCategory(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Entry    types.Entry
+		Expected string
+	}{
+		{Name: "Should map fee debit", Entry: types.Entry{Type: "fee", Operation: types.EntryDebit}, Expected: "105"},
+		{Name: "Should map fee reversal to a credit category", Entry: types.Entry{Type: "fee_reversal", Operation: types.EntryCredit}, Expected: "204"},
+		{Name: "Should map internal transfer credit", Entry: types.Entry{Type: "internal_transfer", Operation: types.EntryCredit}, Expected: "213"},
+		{Name: "Should map pix debit", Entry: types.Entry{Type: "pix_outgoing", Operation: types.EntryDebit}, Expected: "120"},
+		{Name: "Should map unknown credit", Entry: types.Entry{Type: "other", Operation: types.EntryCredit}, Expected: "218"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			got := cnabCategory(testCase.Entry)
+
+			// Asserts
+			if got != testCase.Expected {
+				t.Errorf("cnabCategory() = %q, expected %q", got, testCase.Expected)
+			}
+			if debit := testCase.Entry.Operation == types.EntryDebit; debit != (got[0] == '1') {
+				t.Errorf("cnabCategory() = %q does not match the %s operation", got, testCase.Entry.Operation)
+			}
+
+			statement := testStatement()
+			testCase.Entry.Amount = 100
+			statement.Entries = []types.Entry{testCase.Entry}
+			var buf bytes.Buffer
+			if err := WriteCNAB240(&buf, statement); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			segmentE := strings.Split(buf.String(), "\r\n")[2]
+			if got := segmentE[169:176]; got != testCase.Expected+"0"+testCase.Expected {
+				t.Errorf("segment E category = %q, expected %q", got, testCase.Expected+"0"+testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/accounts/acc-1":
+			_, _ = w.Write([]byte(`{"id":"acc-1","branch_code":"0001","account_code":"1234567"}`))
+		case "/api/v1/accounts/acc-1/statement":
+			if r.URL.Query().Get("after") == "" {
+				_, _ = w.Write([]byte(`{"cursor":{"after":"next"},"data":[{"id":"e-1","amount":100}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"cursor":{},"data":[{"id":"e-2","amount":200}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := openbank.SetBaseURL(server.URL)
+	c, _ := openbank.NewClient(baseURL)
+
+	s, err := Fetch(context.Background(), c, "acc-1", types.StatementFilter{})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if s.Account.AccountCode != "1234567" || len(s.Entries) != 2 {
+		t.Errorf("unexpected statement %+v", s)
+	}
+}
@@ -0,0 +1,137 @@
+package statementexport
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// WriteOFX writes the statement as an OFX 2.2 bank statement response.
+func WriteOFX(w io.Writer, s *Statement) error {
+	o := &ofxWriter{w: bufio.NewWriter(w)}
+	_, closing := s.balances()
+	generated := ofxDate(s.generatedAt())
+
+	o.raw(ofxHeader)
+	o.open("OFX")
+
+	o.open("SIGNONMSGSRSV1")
+	o.open("SONRS")
+	o.status()
+	o.element("DTSERVER", generated)
+	o.element("LANGUAGE", "POR")
+	o.open("FI")
+	o.element("ORG", stoneBankName)
+	o.element("FID", StoneBankCode)
+	o.close("FI")
+	o.close("SONRS")
+	o.close("SIGNONMSGSRSV1")
+
+	o.open("BANKMSGSRSV1")
+	o.open("STMTTRNRS")
+	o.element("TRNUID", "1")
+	o.status()
+	o.open("STMTRS")
+	o.element("CURDEF", "BRL")
+	o.open("BANKACCTFROM")
+	o.element("BANKID", StoneBankCode)
+	o.element("BRANCHID", s.Account.BranchCode)
+	o.element("ACCTID", s.Account.AccountCode)
+	o.element("ACCTTYPE", "CHECKING")
+	o.close("BANKACCTFROM")
+
+	o.open("BANKTRANLIST")
+	o.element("DTSTART", ofxDate(s.Start))
+	o.element("DTEND", ofxDate(s.End))
+	for _, e := range s.sortedEntries() {
+		o.open("STMTTRN")
+		o.element("TRNTYPE", ofxTransactionType(e))
+		o.element("DTPOSTED", ofxDate(e.CreatedAt))
+		o.element("TRNAMT", signedAmount(e).Decimal())
+		o.element("FITID", e.ID)
+		o.element("MEMO", entryDescription(e))
+		o.close("STMTTRN")
+	}
+	o.close("BANKTRANLIST")
+
+	o.open("LEDGERBAL")
+	o.element("BALAMT", closing.Decimal())
+	o.element("DTASOF", ofxDate(s.End))
+	o.close("LEDGERBAL")
+
+	o.close("STMTRS")
+	o.close("STMTTRNRS")
+	o.close("BANKMSGSRSV1")
+	o.close("OFX")
+
+	if o.err != nil {
+		return o.err
+	}
+	return o.w.Flush()
+}
+
+func ofxTransactionType(e types.Entry) string {
+	if e.Operation == types.EntryDebit {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+// ofxDate formats t in Brasília time, e.g. "20250102150405.000[-3:BRT]".
+func ofxDate(t time.Time) string {
+	return t.In(brt).Format("20060102150405.000") + "[-3:BRT]"
+}
+
+// ofxWriter writes indented OFX elements, keeping the first error.
+type ofxWriter struct {
+	w     *bufio.Writer
+	depth int
+	err   error
+}
+
+func (o *ofxWriter) raw(s string) {
+	if o.err == nil {
+		_, o.err = o.w.WriteString(s)
+	}
+}
+
+func (o *ofxWriter) indent() {
+	o.raw(strings.Repeat("  ", o.depth))
+}
+
+func (o *ofxWriter) open(tag string) {
+	o.indent()
+	o.raw(fmt.Sprintf("<%s>\n", tag))
+	o.depth++
+}
+
+func (o *ofxWriter) close(tag string) {
+	o.depth--
+	o.indent()
+	o.raw(fmt.Sprintf("</%s>\n", tag))
+}
+
+func (o *ofxWriter) element(tag, value string) {
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(value)); err != nil && o.err == nil {
+		o.err = err
+	}
+	o.indent()
+	o.raw(fmt.Sprintf("<%s>%s</%s>\n", tag, escaped.String(), tag))
+}
+
+func (o *ofxWriter) status() {
+	o.open("STATUS")
+	o.element("CODE", "0")
+	o.element("SEVERITY", "INFO")
+	o.close("STATUS")
+}
@@ -1,12 +1,192 @@
 package openbank
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// defaultAssertionLifetime is how long client assertions are valid unless WithAssertionLifetime is used.
+const defaultAssertionLifetime = 2 * time.Hour
+
+// signerMethod is a jwt.SigningMethod that delegates signing to a crypto.Signer, so the private key can live in an
+// HSM or KMS instead of in memory.
+type signerMethod struct {
+	alg  string
+	hash crypto.Hash
+	opts crypto.SignerOpts
+
+	// curve is set for ECDSA methods, whose signatures are converted from ASN.1 to the JWS r || s form.
+	curve elliptic.Curve
+}
+
+var signingMethods = map[string]*signerMethod{
+	"RS256": {alg: "RS256", hash: crypto.SHA256, opts: crypto.SHA256},
+	"PS256": {alg: "PS256", hash: crypto.SHA256, opts: &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}},
+	"ES256": {alg: "ES256", hash: crypto.SHA256, opts: crypto.SHA256, curve: elliptic.P256()},
+}
+
+// WithSigningAlgorithm sets the JWS algorithm of client assertions: RS256 (default for RSA keys), PS256 or ES256
+// (default for P-256 keys). NewClient fails when the algorithm does not match the key type.
+func WithSigningAlgorithm(alg string) ClientOpt {
+	return func(c *Client) {
+		c.signingAlg = alg
+	}
+}
+
+// WithAssertionLifetime sets how long client assertions are valid. Defaults to two hours. It fails when d is not
+// positive.
+func WithAssertionLifetime(d time.Duration) (ClientOpt, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid assertion lifetime %v: must be positive", d)
+	}
+	return func(c *Client) {
+		c.assertionLifetime = d
+	}, nil
+}
+
+// WithAssertionClaims adds claims to client assertions. They take precedence over the claims set by the library.
+func WithAssertionClaims(claims map[string]interface{}) ClientOpt {
+	return func(c *Client) {
+		if c.assertionClaims == nil {
+			c.assertionClaims = make(map[string]interface{}, len(claims))
+		}
+		for k, v := range claims {
+			c.assertionClaims[k] = v
+		}
+	}
+}
+
+// setupSigning resolves the signing method for the configured key and derives its key ID.
+func (c *Client) setupSigning() error {
+	if c.signer == nil {
+		if c.signingAlg != "" {
+			return fmt.Errorf("signing algorithm %s requires a private key or signer", c.signingAlg)
+		}
+		return nil
+	}
+
+	pub := c.signer.Public()
+	alg := c.signingAlg
+	if alg == "" {
+		alg = "RS256"
+		if _, ok := pub.(*ecdsa.PublicKey); ok {
+			alg = "ES256"
+		}
+	}
+
+	method, ok := signingMethods[alg]
+	if !ok {
+		return fmt.Errorf("unsupported signing algorithm %s", alg)
+	}
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		if method.curve != nil {
+			return fmt.Errorf("signing algorithm %s requires an ECDSA key", alg)
+		}
+	case *ecdsa.PublicKey:
+		if method.curve == nil || key.Curve != method.curve {
+			return fmt.Errorf("signing algorithm %s does not match the ECDSA key curve", alg)
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", pub)
+	}
+
+	kid, err := keyThumbprint(pub)
+	if err != nil {
+		return err
+	}
+
+	c.signingMethod = method
+	c.keyID = kid
+	return nil
+}
+
+// keyThumbprint returns the RFC 7638 JWK thumbprint of key, used as the kid of client assertions.
+func keyThumbprint(key crypto.PublicKey) (string, error) {
+	jwk := jose.JSONWebKey{Key: key}
+	sum, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(sum), nil
+}
+
+func (m *signerMethod) Alg() string {
+	return m.alg
+}
+
+func (m *signerMethod) Verify(signingString, signature string, key interface{}) error {
+	return jwt.GetSigningMethod(m.alg).Verify(signingString, signature, key)
+}
+
+func (m *signerMethod) Sign(signingString string, key interface{}) (string, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	sig, err := signPayload(signer, m.hash, m.opts, []byte(signingString))
+	if err != nil {
+		return "", err
+	}
+
+	if m.curve != nil {
+		sig, err = ecdsaJWSSignature(sig, m.curve)
+		if err != nil {
+			return "", err
+		}
+	}
+	return jwt.EncodeSegment(sig), nil
+}
+
+// signPayload hashes payload and signs the digest with signer. Every signature produced by the library goes through
+// it, so it works the same for in-memory keys and remote signers.
+func signPayload(signer crypto.Signer, hash crypto.Hash, opts crypto.SignerOpts, payload []byte) ([]byte, error) {
+	if !hash.Available() {
+		return nil, jwt.ErrHashUnavailable
+	}
+	h := hash.New()
+	h.Write(payload)
+	return signer.Sign(rand.Reader, h.Sum(nil), opts)
+}
+
+// ecdsaJWSSignature converts the ASN.1 signature returned by crypto.Signer into the fixed size r || s form of JWS.
+func ecdsaJWSSignature(der []byte, curve elliptic.Curve) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("invalid ECDSA signature: %w", err)
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	sig.R.FillBytes(out[:size])
+	sig.S.FillBytes(out[size:])
+	return out, nil
+}
+
 func (c *Client) generateToken(claims jwt.MapClaims) (string, error) {
-	t := jwt.NewWithClaims(jwt.GetSigningMethod("RS256"), claims)
-	tokenString, err := t.SignedString(c.privateKey)
+	if c.signer == nil || c.signingMethod == nil {
+		return "", errors.New("missing private key or signer")
+	}
+
+	t := jwt.NewWithClaims(c.signingMethod, claims)
+	t.Header["kid"] = c.keyID
+	tokenString, err := t.SignedString(c.signer)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,149 @@
+package openbank
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func testPEMECPrivateKey(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	der, _ := x509.MarshalECPrivateKey(key)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestGenerateTokenAlgorithms(t *testing.T) {
+	rsaKey, ecKey := testPEMPrivateKey(t), testPEMECPrivateKey(t)
+
+	testCases := []struct {
+		Name        string
+		Key         []byte
+		Algorithm   string
+		ExpectedAlg string
+	}{
+		{Name: "Should default to RS256 for RSA keys", Key: rsaKey, ExpectedAlg: "RS256"},
+		{Name: "Should sign with PS256", Key: rsaKey, Algorithm: "PS256", ExpectedAlg: "PS256"},
+		{Name: "Should default to ES256 for P-256 keys", Key: ecKey, ExpectedAlg: "ES256"},
+		{Name: "Should sign with ES256", Key: ecKey, Algorithm: "ES256", ExpectedAlg: "ES256"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			opts := []ClientOpt{WithClientID("client-id"), WithPEMPrivateKey(testCase.Key)}
+			if testCase.Algorithm != "" {
+				opts = append(opts, WithSigningAlgorithm(testCase.Algorithm))
+			}
+			c, err := NewClient(opts...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			// Act
+			tokenString, err := c.generateToken(c.authClaims())
+			if err != nil {
+				t.Fatalf("generateToken() error = %v", err)
+			}
+
+			// Asserts
+			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+				return c.signer.Public(), nil
+			})
+			if err != nil || !token.Valid {
+				t.Fatalf("invalid client assertion: %v", err)
+			}
+			if token.Method.Alg() != testCase.ExpectedAlg {
+				t.Errorf("alg = %s, expected %s", token.Method.Alg(), testCase.ExpectedAlg)
+			}
+			if kid, _ := keyThumbprint(c.signer.Public()); token.Header["kid"] != kid || kid == "" {
+				t.Errorf("kid = %v, expected %v", token.Header["kid"], kid)
+			}
+		})
+	}
+}
+
+func TestSigningAlgorithmMustMatchKey(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Key       []byte
+		Algorithm string
+	}{
+		{Name: "Should reject ES256 with RSA key", Key: testPEMPrivateKey(t), Algorithm: "ES256"},
+		{Name: "Should reject PS256 with ECDSA key", Key: testPEMECPrivateKey(t), Algorithm: "PS256"},
+		{Name: "Should reject unknown algorithm", Key: testPEMPrivateKey(t), Algorithm: "HS256"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := NewClient(WithPEMPrivateKey(testCase.Key), WithSigningAlgorithm(testCase.Algorithm))
+			if err == nil {
+				t.Error("expected err got nil")
+			}
+		})
+	}
+}
+
+func TestAuthClaimsOptions(t *testing.T) {
+	lifetime, err := WithAssertionLifetime(time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, _ := NewClient(
+		WithClientID("client-id"),
+		lifetime,
+		WithAssertionClaims(map[string]interface{}{"realm": "custom", "scope": "pix"}),
+	)
+
+	claims := c.authClaims()
+
+	if exp, iat := claims["exp"].(int64), claims["iat"].(int64); exp-iat != 60 {
+		t.Errorf("assertion lifetime = %ds, expected 60s", exp-iat)
+	}
+	if claims["realm"] != "custom" || claims["scope"] != "pix" {
+		t.Errorf("extra claims not applied: %v", claims)
+	}
+	if claims["sub"] != "client-id" {
+		t.Errorf("sub = %v, expected client-id", claims["sub"])
+	}
+}
+
+func TestWithAssertionLifetime(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Lifetime      time.Duration
+		ExpectedError bool
+	}{
+		{Name: "Should accept positive lifetime", Lifetime: time.Minute},
+		{Name: "Should reject zero lifetime", Lifetime: 0, ExpectedError: true},
+		{Name: "Should reject negative lifetime", Lifetime: -time.Minute, ExpectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			opt, err := WithAssertionLifetime(testCase.Lifetime)
+
+			// Asserts
+			if testCase.ExpectedError {
+				if err == nil || opt != nil {
+					t.Errorf("WithAssertionLifetime(%v) = %v, expected an error", testCase.Lifetime, err)
+				}
+				return
+			}
+			if err != nil || opt == nil {
+				t.Errorf("WithAssertionLifetime(%v) error = %v", testCase.Lifetime, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package openbank
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrTokenNotFound is returned by TokenStore.Get when there is no token stored under the key.
+var ErrTokenNotFound = errors.New("openbank: token not found")
+
+// TokenStore persists access tokens so that several clients, possibly in different processes, can share them instead
+// of each one requesting its own token.
+//
+// Implementations must be safe for concurrent use and honor the following contract:
+//
+//   - Get returns the token stored under key, or ErrTokenNotFound. Returning an expired token is allowed, the client
+//     validates it before use.
+//   - Set stores token under key, replacing any previous value. Stores with native expiration (e.g. Redis) should
+//     expire the entry at token.Expiry, or at the "exp" claim of the access token when Expiry is zero.
+//   - Lock blocks until it acquires an exclusive lock on key, or ctx is done. The returned function releases it and
+//     must be safe to call once. Locks held by a crashed process must eventually be released, a lease of a few
+//     seconds (e.g. SET key NX PX) is enough since the lock only guards a single token request.
+//
+// Errors from Get and Set are logged and the client falls back to requesting a token; errors from Lock abort the
+// authentication.
+type TokenStore interface {
+	Get(ctx context.Context, key string) (*oauth2.Token, error)
+	Set(ctx context.Context, key string, token *oauth2.Token) error
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+}
+
+// WithTokenStore makes the client read and write its access tokens through store.
+func WithTokenStore(store TokenStore) ClientOpt {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// MemoryTokenStore is a TokenStore that shares tokens between clients of the same process.
+type MemoryTokenStore struct {
+	m      sync.Mutex
+	tokens map[string]oauth2.Token
+	locks  map[string]chan struct{}
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]oauth2.Token),
+		locks:  make(map[string]chan struct{}),
+	}
+}
+
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (*oauth2.Token, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Set(_ context.Context, key string, token *oauth2.Token) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.tokens[key] = *token
+	return nil
+}
+
+func (s *MemoryTokenStore) Lock(ctx context.Context, key string) (func(), error) {
+	s.m.Lock()
+	sem, ok := s.locks[key]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		s.locks[key] = sem
+	}
+	s.m.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-sem }) }, nil
+}
+
+const fileTokenStoreLockPoll = 50 * time.Millisecond
+
+// FileTokenStore is a TokenStore that keeps tokens as JSON files in Dir, so CLI invocations and processes on the same
+// host can share them. Files are written with 0600 permissions. Locks are operating system locks (flock, or LockFileEx
+// on Windows) on lock files kept next to them, released by the system when the process holding them dies.
+type FileTokenStore struct {
+	Dir string
+}
+
+// NewFileTokenStore creates a FileTokenStore in dir, creating the directory if needed.
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileTokenStore{Dir: dir}, nil
+}
+
+func (s *FileTokenStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *FileTokenStore) Get(_ context.Context, key string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("decoding stored token: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *FileTokenStore) Set(_ context.Context, key string, token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+func (s *FileTokenStore) Lock(ctx context.Context, key string) (func(), error) {
+	f, err := os.OpenFile(s.path(key)+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if locked {
+			var once sync.Once
+			return func() {
+				once.Do(func() {
+					_ = unlockFile(f)
+					f.Close()
+				})
+			}, nil
+		}
+
+		t := time.NewTimer(fileTokenStoreLockPoll)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			f.Close()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
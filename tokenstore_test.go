@@ -0,0 +1,115 @@
+package openbank
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenStores(t *testing.T) {
+	fileStore, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error creating file store: %v", err)
+	}
+
+	testCases := []struct {
+		Name  string
+		Store TokenStore
+	}{
+		{Name: "MemoryTokenStore", Store: NewMemoryTokenStore()},
+		{Name: "FileTokenStore", Store: fileStore},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, err := testCase.Store.Get(ctx, "key"); !errors.Is(err, ErrTokenNotFound) {
+				t.Errorf("Get() on empty store error = %v, expected ErrTokenNotFound", err)
+			}
+
+			expiry := time.Now().Add(time.Hour).Round(time.Second)
+			if err := testCase.Store.Set(ctx, "key", &oauth2.Token{AccessToken: "abc", Expiry: expiry}); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			token, err := testCase.Store.Get(ctx, "key")
+			if err != nil || token.AccessToken != "abc" || !token.Expiry.Equal(expiry) {
+				t.Errorf("Get() = %+v, %v", token, err)
+			}
+
+			unlock, err := testCase.Store.Lock(ctx, "key")
+			if err != nil {
+				t.Fatalf("Lock() error = %v", err)
+			}
+
+			timeout, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			if _, err := testCase.Store.Lock(timeout, "key"); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Lock() on held key error = %v, expected context.DeadlineExceeded", err)
+			}
+
+			unlock()
+			unlock()
+			unlockAgain, err := testCase.Store.Lock(ctx, "key")
+			if err != nil {
+				t.Fatalf("Lock() after unlock error = %v", err)
+			}
+			unlockAgain()
+		})
+	}
+}
+
+func TestFileTokenStoreLeftoverLockFile(t *testing.T) {
+	// Arrange
+	store, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error creating file store: %v", err)
+	}
+	if err := os.WriteFile(store.path("key")+".lock", nil, 0o600); err != nil {
+		t.Fatalf("error creating lock file: %v", err)
+	}
+
+	// Act
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := store.Lock(ctx, "key")
+
+	// Asserts
+	if err != nil {
+		t.Fatalf("Lock() with a lock file left by a dead process error = %v", err)
+	}
+	unlock()
+}
+
+func TestClientsShareTokenStore(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	store := NewMemoryTokenStore()
+
+	clients := make([]*Client, 5)
+	for i := range clients {
+		clients[i] = newAuthTestClient(t, s)
+		clients[i].ApplyOpts(WithTokenStore(store))
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			if err := c.Authenticate(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	if got := s.tokenCalls.Load(); got != 1 {
+		t.Errorf("token requests = %d, expected 1", got)
+	}
+}
@@ -0,0 +1,143 @@
+package openbank
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// TransfersService handles transfers between accounts.
+type TransfersService struct {
+	client *Client
+}
+
+// CreateInternal transfers money to another Stone account. When input.IdempotencyKey is empty a random key is
+// generated and stored in input, so calling CreateInternal again with the same input can not transfer twice.
+//
+// Failures carry a *TransferError, with the validation errors reported by Stone, retrievable with errors.As.
+func (s *TransfersService) CreateInternal(ctx context.Context, input *types.InternalTransferInput) (*types.InternalTransfer, *Response, error) {
+	var transfer types.InternalTransfer
+	resp, err := s.client.postIdempotent(ctx, "/api/v1/internal_transfers", input, &input.IdempotencyKey, &transfer)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &transfer, resp, nil
+}
+
+// CreateExternal sends a TED to an account at another bank. The input is validated locally first: invalid inputs fail
+// with an error matching ErrValidation that carries a *TransferError, without calling Stone. Idempotency keys are
+// handled as in CreateInternal.
+func (s *TransfersService) CreateExternal(ctx context.Context, input *types.ExternalTransferInput) (*types.ExternalTransfer, *Response, error) {
+	if err := validateExternalTransfer(input); err != nil {
+		return nil, nil, err
+	}
+	inferDocumentType(&input.Beneficiary.Entity)
+
+	var transfer types.ExternalTransfer
+	resp, err := s.client.postIdempotent(ctx, "/api/v1/external_transfers", input, &input.IdempotencyKey, &transfer)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &transfer, resp, nil
+}
+
+// GetInternal returns the internal transfer identified by transferID.
+func (s *TransfersService) GetInternal(ctx context.Context, transferID string) (*types.InternalTransfer, *Response, error) {
+	path, err := apiPath("/api/v1/internal_transfers/%s", transferID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transfer types.InternalTransfer
+	resp, err := s.client.Do(req.WithContext(ctx), &transfer, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &transfer, resp, nil
+}
+
+// ListInternal iterates lazily over the internal transfers of the account, following the pagination cursor.
+// Iteration stops at the first error.
+func (s *TransfersService) ListInternal(ctx context.Context, accountID string, filter types.TransferFilter) iter.Seq2[types.InternalTransfer, error] {
+	path, err := apiPath("/api/v1/accounts/%s/internal_transfers", accountID)
+	return paginate(filter.After, func(after string) ([]types.InternalTransfer, string, error) {
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+		return fetchPage[types.InternalTransfer](ctx, s.client, path, transferQuery(filter))
+	})
+}
+
+// CancelInternal cancels a scheduled internal transfer. Transfers already executed can not be canceled.
+func (s *TransfersService) CancelInternal(ctx context.Context, transferID string) (*Response, error) {
+	path, err := apiPath("/api/v1/internal_transfers/%s/cancel", transferID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req.WithContext(ctx), nil, nil)
+}
+
+func transferQuery(filter types.TransferFilter) url.Values {
+	q := url.Values{}
+	if filter.Status != "" {
+		q.Set("status", filter.Status)
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.After != "" {
+		q.Set("after", filter.After)
+	}
+	return q
+}
+
+// GetExternal returns the external transfer identified by transferID.
+func (s *TransfersService) GetExternal(ctx context.Context, transferID string) (*types.ExternalTransfer, *Response, error) {
+	path, err := apiPath("/api/v1/external_transfers/%s", transferID)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transfer types.ExternalTransfer
+	resp, err := s.client.Do(req.WithContext(ctx), &transfer, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &transfer, resp, nil
+}
+
+// CancelExternal cancels a scheduled external transfer.
+func (s *TransfersService) CancelExternal(ctx context.Context, transferID string) (*Response, error) {
+	path, err := apiPath("/api/v1/external_transfers/%s/cancel", transferID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewAPIRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req.WithContext(ctx), nil, nil)
+}
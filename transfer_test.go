@@ -0,0 +1,198 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestTransfersCreateInternal(t *testing.T) {
+	var gotKey string
+	var gotBody map[string]interface{}
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get(idempotencyHeader)
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		if gotBody["amount"] == float64(0) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"type":"srn:error:validation","validation_errors":[{"error":"must be greater than 0","path":["amount"]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"tr-1","amount":1050,"status":"SCHEDULED","scheduled_to":"2025-03-10"}`))
+	})
+
+	testCases := []struct {
+		Name          string
+		Input         types.InternalTransferInput
+		ExpectedKey   string
+		ExpectedError bool
+	}{
+		{
+			Name:        "Should send the given idempotency key",
+			Input:       types.InternalTransferInput{AccountID: "acc-1", Amount: 1050, IdempotencyKey: "transfer-1"},
+			ExpectedKey: "transfer-1",
+		},
+		{
+			Name:  "Should generate an idempotency key",
+			Input: types.InternalTransferInput{AccountID: "acc-1", Amount: 1050},
+		},
+		{
+			Name:          "Should reject idempotency keys that are too long",
+			Input:         types.InternalTransferInput{AccountID: "acc-1", Amount: 1050, IdempotencyKey: strings.Repeat("k", idempotencyKeyMaxSize+1)},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			gotKey = ""
+			date := types.NewDate(2025, time.March, 10)
+			testCase.Input.ScheduledTo = &date
+
+			// Act
+			transfer, _, err := c.Transfers.CreateInternal(context.Background(), &testCase.Input)
+
+			// Asserts
+			if testCase.ExpectedError {
+				if err == nil {
+					t.Error("expected error")
+				}
+				if gotKey != "" {
+					t.Error("request should not be sent")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateInternal() error = %v", err)
+			}
+			if gotKey == "" || gotKey != testCase.Input.IdempotencyKey {
+				t.Errorf("idempotency key = %q, input key = %q", gotKey, testCase.Input.IdempotencyKey)
+			}
+			if testCase.ExpectedKey != "" && gotKey != testCase.ExpectedKey {
+				t.Errorf("idempotency key = %q, expected %q", gotKey, testCase.ExpectedKey)
+			}
+			if gotBody["scheduled_to"] != "2025-03-10" {
+				t.Errorf("scheduled_to = %v", gotBody["scheduled_to"])
+			}
+			if transfer.Status != types.TransferStatusScheduled || transfer.ScheduledTo.String() != "2025-03-10" {
+				t.Errorf("unexpected transfer %+v", transfer)
+			}
+		})
+	}
+
+	t.Run("Should decode validation errors", func(t *testing.T) {
+		_, _, err := c.Transfers.CreateInternal(context.Background(), &types.InternalTransferInput{AccountID: "acc-1"})
+
+		var te *TransferError
+		if !errors.Is(err, ErrValidation) || !errors.As(err, &te) {
+			t.Fatalf("expected validation TransferError, got %v", err)
+		}
+		if len(te.ValidationErrors) != 1 || te.ValidationErrors[0].Path[0] != "amount" {
+			t.Errorf("unexpected validation errors %+v", te.ValidationErrors)
+		}
+	})
+}
+
+func TestTransfersInternal(t *testing.T) {
+	var canceled string
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/internal_transfers/tr-1":
+			_, _ = w.Write([]byte(`{"id":"tr-1","amount":500,"status":"FINISHED"}`))
+		case r.URL.Path == "/api/v1/internal_transfers/tr-2/cancel" && r.Method == http.MethodPost:
+			canceled = "tr-2"
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/v1/accounts/acc-1/internal_transfers":
+			if r.URL.Query().Get("status") != types.TransferStatusScheduled {
+				t.Errorf("status filter = %q", r.URL.Query().Get("status"))
+			}
+			if r.URL.Query().Get("after") == "" {
+				_, _ = w.Write([]byte(`{"cursor":{"after":"c1"},"data":[{"id":"tr-2"},{"id":"tr-3"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"cursor":{},"data":[{"id":"tr-4"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	t.Run("Should get transfer", func(t *testing.T) {
+		transfer, _, err := c.Transfers.GetInternal(ctx, "tr-1")
+		if err != nil || transfer.Amount != 500 || transfer.Status != types.TransferStatusFinished {
+			t.Errorf("GetInternal() = %+v, %v", transfer, err)
+		}
+	})
+
+	t.Run("Should list transfers across pages", func(t *testing.T) {
+		var ids []string
+		for transfer, err := range c.Transfers.ListInternal(ctx, "acc-1", types.TransferFilter{Status: types.TransferStatusScheduled}) {
+			if err != nil {
+				t.Fatalf("ListInternal() error = %v", err)
+			}
+			ids = append(ids, transfer.ID)
+		}
+		if strings.Join(ids, ",") != "tr-2,tr-3,tr-4" {
+			t.Errorf("listed %v", ids)
+		}
+	})
+
+	t.Run("Should cancel transfer", func(t *testing.T) {
+		if _, err := c.Transfers.CancelInternal(ctx, "tr-2"); err != nil || canceled != "tr-2" {
+			t.Errorf("CancelInternal() error = %v, canceled %q", err, canceled)
+		}
+	})
+}
+
+func TestTransfersCreateExternal(t *testing.T) {
+	var calls int
+	var gotKey string
+	var body types.ExternalTransferInput
+
+	c := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotKey = r.Header.Get(idempotencyHeader)
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/external_transfers" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"ted-1","amount":10000,"status":"CREATED"}`))
+	})
+
+	input := &types.ExternalTransferInput{
+		AccountID: "acc-1",
+		Amount:    10000,
+		Beneficiary: types.Beneficiary{
+			Account: types.BankAccount{BankCode: "341", BranchCode: "0001", AccountCode: "123456", AccountType: types.AccountTypeChecking},
+			Entity:  types.Entity{Name: "Fulano", Document: "529.982.247-25"},
+		},
+	}
+
+	transfer, _, err := c.Transfers.CreateExternal(context.Background(), input)
+	if err != nil || transfer.ID != "ted-1" {
+		t.Fatalf("CreateExternal() = %+v, %v", transfer, err)
+	}
+	if gotKey == "" || gotKey != input.IdempotencyKey {
+		t.Errorf("idempotency key = %q, input key = %q", gotKey, input.IdempotencyKey)
+	}
+	if body.Beneficiary.Entity.DocumentType != types.DocumentTypeCPF {
+		t.Errorf("document_type = %q, expected it inferred as %q", body.Beneficiary.Entity.DocumentType, types.DocumentTypeCPF)
+	}
+
+	input.Beneficiary.Entity.Document = "529.982.247-00"
+	if _, _, err := c.Transfers.CreateExternal(context.Background(), input); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("invalid input should not reach the API, calls = %d", calls)
+	}
+}
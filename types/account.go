@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// Account statuses.
+const (
+	AccountStatusActive  = "active"
+	AccountStatusBlocked = "blocked"
+	AccountStatusClosed  = "closed"
+)
+
+// Account is a Stone payment account.
+type Account struct {
+	ID            string     `json:"id"`
+	BranchCode    string     `json:"branch_code"`
+	AccountCode   string     `json:"account_code"`
+	OwnerID       string     `json:"owner_id,omitempty"`
+	OwnerName     string     `json:"owner_name,omitempty"`
+	OwnerDocument Document   `json:"owner_document"`
+	Status        string     `json:"status"`
+	Restrictions  []string   `json:"restrictions,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+// Restricted reports whether the account has any restriction in place.
+func (a Account) Restricted() bool {
+	return len(a.Restrictions) > 0
+}
@@ -0,0 +1,11 @@
+package types
+
+// Balance is the balance of an account.
+type Balance struct {
+	// Available is the amount that can be used right away.
+	Available Money `json:"balance"`
+	// Blocked is the amount held by judicial or operational blocks.
+	Blocked Money `json:"blocked_balance"`
+	// Scheduled is the amount committed to scheduled outgoing transactions.
+	Scheduled Money `json:"scheduled_balance"`
+}
@@ -0,0 +1,75 @@
+package types
+
+import "time"
+
+// Barcode payment statuses.
+const (
+	PaymentStatusCreated   = "CREATED"
+	PaymentStatusScheduled = "SCHEDULED"
+	PaymentStatusFinished  = "FINISHED"
+	PaymentStatusFailed    = "FAILED"
+	PaymentStatusCanceled  = "CANCELED"
+)
+
+// Barcode kinds.
+const (
+	// BarcodeBoleto is a bank boleto.
+	BarcodeBoleto = "boleto"
+	// BarcodeConvenio is a utility bill or tax slip, whose barcode starts with 8.
+	BarcodeConvenio = "convenio"
+)
+
+// BarcodeValidation is the result of the consultation of a barcode, with the amounts to be charged at the time of
+// payment.
+type BarcodeValidation struct {
+	// ID identifies the consultation and is sent back in BarcodePaymentInput.
+	ID            string        `json:"id"`
+	Kind          string        `json:"kind"`
+	Barcode       Barcode       `json:"barcode"`
+	DigitableLine DigitableLine `json:"writable_line,omitempty"`
+	// Amount is the original amount of the document.
+	Amount   Money `json:"amount"`
+	Discount Money `json:"discount_amount,omitempty"`
+	Fine     Money `json:"fine_amount,omitempty"`
+	Interest Money `json:"interest_amount,omitempty"`
+	// TotalAmount is the amount to be paid today, with discounts, fine and interest applied.
+	TotalAmount Money `json:"total_amount"`
+	// AllowChangeAmount is set for documents that accept payments of an amount other than TotalAmount.
+	AllowChangeAmount bool   `json:"allow_change_amount,omitempty"`
+	MinAmount         Money  `json:"min_amount,omitempty"`
+	MaxAmount         Money  `json:"max_amount,omitempty"`
+	DueDate           *Date  `json:"due_date,omitempty"`
+	LimitDate         *Date  `json:"limit_date,omitempty"`
+	Beneficiary       Entity `json:"beneficiary"`
+	Payer             Entity `json:"payer,omitempty"`
+}
+
+// BarcodePaymentInput pays a boleto or convenio.
+type BarcodePaymentInput struct {
+	// AccountID is the paying account.
+	AccountID string `json:"account_id"`
+	// Barcode is the 44 digit barcode, or the 47 or 48 digit digitable line, with or without formatting.
+	Barcode string `json:"barcode"`
+	// ValidationID is the ID of the BarcodeValidation the payment was confirmed with.
+	ValidationID string `json:"validation_id,omitempty"`
+	Amount       Money  `json:"amount"`
+	Description  string `json:"description,omitempty"`
+	// ScheduledTo schedules the payment to a future date. When nil the payment is made right away.
+	ScheduledTo *Date `json:"scheduled_to,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// BarcodePayment is the payment of a boleto or convenio.
+type BarcodePayment struct {
+	ID            string     `json:"id"`
+	AccountID     string     `json:"account_id"`
+	Barcode       Barcode    `json:"barcode"`
+	Amount        Money      `json:"amount"`
+	Status        string     `json:"status"`
+	Description   string     `json:"description,omitempty"`
+	ScheduledTo   *Date      `json:"scheduled_to,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// Boleto statuses.
+const (
+	BoletoStatusCreated    = "CREATED"
+	BoletoStatusRegistered = "REGISTERED"
+	BoletoStatusPaid       = "PAID"
+	BoletoStatusCanceled   = "CANCELED"
+	BoletoStatusExpired    = "EXPIRED"
+)
+
+// Barcode is the 44 digit number encoded in the bars of a boleto.
+type Barcode string
+
+// DigitableLine is the "linha digitável" printed above the bars, typed by payers: 47 digits for boletos and 48 for
+// convenios.
+type DigitableLine string
+
+// Formatted groups the digits the way they are printed, e.g. "00190.00009 01234.567893 12345.678909 1 96170000012345"
+// for boletos and "83640000001-1 33120138000-2 81288462711-6 08013618155-1" for convenios. Lines of other lengths are
+// returned unchanged.
+func (l DigitableLine) Formatted() string {
+	s := string(l)
+	switch len(s) {
+	case 47:
+		return strings.Join([]string{
+			s[0:5] + "." + s[5:10],
+			s[10:15] + "." + s[15:21],
+			s[21:26] + "." + s[26:32],
+			s[32:33],
+			s[33:47],
+		}, " ")
+	case 48:
+		return strings.Join([]string{
+			s[0:11] + "-" + s[11:12],
+			s[12:23] + "-" + s[23:24],
+			s[24:35] + "-" + s[35:36],
+			s[36:47] + "-" + s[47:48],
+		}, " ")
+	}
+	return s
+}
+
+// BoletoInput issues a boleto.
+type BoletoInput struct {
+	// AccountID is the account that receives the payment.
+	AccountID string `json:"account_id"`
+	Amount    Money  `json:"amount"`
+	DueDate   Date   `json:"expiration_date"`
+	// LimitDate is the last day the boleto can be paid, with fine and interest. It defaults to the due date.
+	LimitDate *Date            `json:"limit_date,omitempty"`
+	Payer     BoletoPayer      `json:"payer"`
+	Fine      *ChargeFine      `json:"fine,omitempty"`
+	Interest  *ChargeInterest  `json:"interest,omitempty"`
+	Discounts []ChargeDiscount `json:"discounts,omitempty"`
+	// Instructions are printed on the boleto.
+	Instructions string `json:"instructions,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// BoletoPayer is the customer that must pay a boleto.
+type BoletoPayer struct {
+	Name string `json:"name"`
+	// Document is the CPF or CNPJ of the payer.
+	Document Document `json:"document"`
+	Address  *Address `json:"address,omitempty"`
+}
+
+// Address is a postal address.
+type Address struct {
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement,omitempty"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	PostalCode   string `json:"postal_code"`
+}
+
+// Boleto is an issued boleto.
+type Boleto struct {
+	ID            string           `json:"id"`
+	AccountID     string           `json:"account_id"`
+	Amount        Money            `json:"amount"`
+	Status        string           `json:"status"`
+	DueDate       Date             `json:"expiration_date"`
+	LimitDate     *Date            `json:"limit_date,omitempty"`
+	Payer         BoletoPayer      `json:"payer"`
+	Fine          *ChargeFine      `json:"fine,omitempty"`
+	Interest      *ChargeInterest  `json:"interest,omitempty"`
+	Discounts     []ChargeDiscount `json:"discounts,omitempty"`
+	Instructions  string           `json:"instructions,omitempty"`
+	Barcode       Barcode          `json:"barcode"`
+	DigitableLine DigitableLine    `json:"writable_line"`
+	OurNumber     string           `json:"our_number,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	PaidAt        *time.Time       `json:"paid_at,omitempty"`
+	PaidAmount    Money            `json:"paid_amount,omitempty"`
+}
+
+// BoletoFilter selects the boletos returned by a listing. Zero values are ignored.
+type BoletoFilter struct {
+	Status string
+	Start  time.Time
+	End    time.Time
+
+	// Limit is the page size.
+	Limit int
+	// After resumes the listing from a cursor returned in a previous page.
+	After string
+}
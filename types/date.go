@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day, encoded as "2006-01-02". It is used for scheduling and due dates.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the given calendar date.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date in the "2006-01-02" layout.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a "2006-01-02" date, also accepting a full RFC 3339 timestamp.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	if len(s) > len(dateLayout) {
+		s = s[:len(dateLayout)]
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid date %s: %w", data, err)
+	}
+	d.Time = t
+	return nil
+}
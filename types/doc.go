@@ -0,0 +1,6 @@
+// Package types holds the requests and responses of the Stone Open Banking API.
+//
+// Inputs of requests that move money or create resources have an IdempotencyKey field, sent in the
+// x-stone-idempotency-key header instead of the body. When it is empty, a random key is generated and stored in the
+// input, so that sending the same input again, e.g. after a timeout, can not execute the request twice.
+package types
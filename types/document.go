@@ -0,0 +1,277 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Document types, as in Entity.DocumentType.
+const (
+	DocumentTypeCPF  = "cpf"
+	DocumentTypeCNPJ = "cnpj"
+)
+
+// ErrInvalidDocument is returned when a value is not a CPF or CNPJ with valid check digits.
+var ErrInvalidDocument = errors.New("invalid document")
+
+var documentPunctuation = strings.NewReplacer(".", "", "-", "", "/", "", " ", "")
+
+// CPF is the taxpayer number of a person, 11 digits whose last two are check digits. Values are normalized to digits
+// only when parsed or encoded, so formatted literals such as CPF("529.982.247-25") are accepted.
+type CPF string
+
+// ParseCPF parses a CPF, formatted or not, checking its check digits.
+func ParseCPF(s string) (CPF, error) {
+	c := CPF(normalizeDocument(s))
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %q is not a CPF", ErrInvalidDocument, s)
+	}
+	return c, nil
+}
+
+// Valid reports whether c has 11 digits, not all equal, with valid check digits.
+func (c CPF) Valid() bool {
+	s := normalizeDocument(string(c))
+	if len(s) != 11 || !isDigits(s) || strings.Count(s, s[:1]) == 11 {
+		return false
+	}
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(s[i]-'0') * (n + 1 - i)
+		}
+		if int(s[n]-'0') != sum*10%11%10 {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalized returns the 11 digits of the CPF, without formatting.
+func (c CPF) Normalized() string {
+	return normalizeDocument(string(c))
+}
+
+// String formats the CPF as "529.982.247-25". Invalid values are returned normalized.
+func (c CPF) String() string {
+	s := c.Normalized()
+	if len(s) != 11 {
+		return s
+	}
+	return s[0:3] + "." + s[3:6] + "." + s[6:9] + "-" + s[9:11]
+}
+
+// Masked formats the CPF hiding its first and check digits, e.g. "***.982.247-**", for logs.
+func (c CPF) Masked() string {
+	s := c.Normalized()
+	if len(s) != 11 {
+		return strings.Repeat("*", len(s))
+	}
+	return "***." + s[3:6] + "." + s[6:9] + "-**"
+}
+
+// MarshalJSON encodes the CPF as digits only.
+func (c CPF) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Normalized())
+}
+
+// UnmarshalJSON decodes a CPF, formatted or not. Check digits are not verified, so that documents masked by Stone
+// can be decoded; use Valid when needed.
+func (c *CPF) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*c = CPF(normalizeDocument(s))
+	return nil
+}
+
+// CNPJ is the taxpayer number of a company: 12 characters followed by two check digits. Besides the numeric format,
+// it supports the alphanumeric one introduced by Receita Federal, whose first 12 characters may be uppercase letters.
+// Values are normalized when parsed or encoded, so formatted literals such as CNPJ("11.222.333/0001-81") are accepted.
+type CNPJ string
+
+// ParseCNPJ parses a CNPJ, formatted or not, checking its check digits. Letters are uppercased.
+func ParseCNPJ(s string) (CNPJ, error) {
+	c := CNPJ(normalizeDocument(s))
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %q is not a CNPJ", ErrInvalidDocument, s)
+	}
+	return c, nil
+}
+
+// Valid reports whether c has 12 digits or uppercase letters, not all equal, followed by two valid check digits.
+// Letters count as their ASCII code minus 48, which keeps numeric CNPJs unchanged.
+func (c CNPJ) Valid() bool {
+	s := normalizeDocument(string(c))
+	if len(s) != 14 || !isDigits(s[12:]) || strings.Count(s, s[:1]) == 14 {
+		return false
+	}
+	for i := 0; i < 12; i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'A' || s[i] > 'Z') {
+			return false
+		}
+	}
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for _, n := range []int{12, 13} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(s[i]-'0') * weights[len(weights)-n+i]
+		}
+		dv := 11 - sum%11
+		if dv >= 10 {
+			dv = 0
+		}
+		if int(s[n]-'0') != dv {
+			return false
+		}
+	}
+	return true
+}
+
+// Root returns the first 8 characters, which identify the company across its branches.
+func (c CNPJ) Root() string {
+	s := normalizeDocument(string(c))
+	if len(s) < 8 {
+		return s
+	}
+	return s[:8]
+}
+
+// Normalized returns the 14 characters of the CNPJ, without formatting.
+func (c CNPJ) Normalized() string {
+	return normalizeDocument(string(c))
+}
+
+// String formats the CNPJ as "11.222.333/0001-81". Invalid values are returned normalized.
+func (c CNPJ) String() string {
+	s := c.Normalized()
+	if len(s) != 14 {
+		return s
+	}
+	return s[0:2] + "." + s[2:5] + "." + s[5:8] + "/" + s[8:12] + "-" + s[12:14]
+}
+
+// Masked formats the CNPJ hiding its branch and check digits, e.g. "11.222.333/****-**", for logs.
+func (c CNPJ) Masked() string {
+	s := c.Normalized()
+	if len(s) != 14 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[0:2] + "." + s[2:5] + "." + s[5:8] + "/****-**"
+}
+
+// MarshalJSON encodes the CNPJ without formatting.
+func (c CNPJ) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Normalized())
+}
+
+// UnmarshalJSON decodes a CNPJ, formatted or not. As with CPF, check digits are not verified.
+func (c *CNPJ) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*c = CNPJ(normalizeDocument(s))
+	return nil
+}
+
+// Document is either a CPF or a CNPJ, told apart by length. It is used where both are accepted, such as payers and
+// beneficiaries.
+type Document string
+
+// ParseDocument parses a CPF or CNPJ, formatted or not, checking its check digits.
+func ParseDocument(s string) (Document, error) {
+	d := Document(normalizeDocument(s))
+	if !d.Valid() {
+		return "", fmt.Errorf("%w: %q is not a CPF or CNPJ", ErrInvalidDocument, s)
+	}
+	return d, nil
+}
+
+// Type returns DocumentTypeCPF or DocumentTypeCNPJ according to the length of d, or "" for other lengths.
+func (d Document) Type() string {
+	switch len(d.Normalized()) {
+	case 11:
+		return DocumentTypeCPF
+	case 14:
+		return DocumentTypeCNPJ
+	}
+	return ""
+}
+
+// CPF returns the document as a CPF, or false when it is not one.
+func (d Document) CPF() (CPF, bool) {
+	if d.Type() != DocumentTypeCPF {
+		return "", false
+	}
+	return CPF(d.Normalized()), true
+}
+
+// CNPJ returns the document as a CNPJ, or false when it is not one.
+func (d Document) CNPJ() (CNPJ, bool) {
+	if d.Type() != DocumentTypeCNPJ {
+		return "", false
+	}
+	return CNPJ(d.Normalized()), true
+}
+
+// Valid reports whether d is a valid CPF or CNPJ.
+func (d Document) Valid() bool {
+	switch d.Type() {
+	case DocumentTypeCPF:
+		return CPF(d).Valid()
+	case DocumentTypeCNPJ:
+		return CNPJ(d).Valid()
+	}
+	return false
+}
+
+// Normalized returns the document without formatting.
+func (d Document) Normalized() string {
+	return normalizeDocument(string(d))
+}
+
+// String formats the document as a CPF or CNPJ.
+func (d Document) String() string {
+	switch d.Type() {
+	case DocumentTypeCPF:
+		return CPF(d).String()
+	case DocumentTypeCNPJ:
+		return CNPJ(d).String()
+	}
+	return d.Normalized()
+}
+
+// Masked formats the document hiding part of it, for logs. See CPF.Masked and CNPJ.Masked.
+func (d Document) Masked() string {
+	switch d.Type() {
+	case DocumentTypeCPF:
+		return CPF(d).Masked()
+	case DocumentTypeCNPJ:
+		return CNPJ(d).Masked()
+	}
+	return strings.Repeat("*", len(d.Normalized()))
+}
+
+// MarshalJSON encodes the document without formatting.
+func (d Document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Normalized())
+}
+
+// UnmarshalJSON decodes a document, formatted or not. As with CPF, check digits are not verified.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*d = Document(normalizeDocument(s))
+	return nil
+}
+
+// normalizeDocument removes the punctuation of formatted documents and uppercases letters.
+func normalizeDocument(s string) string {
+	return strings.ToUpper(documentPunctuation.Replace(strings.TrimSpace(s)))
+}
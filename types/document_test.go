@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    string
+		Expected Document
+		Type     string
+		Error    bool
+	}{
+		{Name: "Should accept formatted CPF", Input: "529.982.247-25", Expected: "52998224725", Type: DocumentTypeCPF},
+		{Name: "Should accept CPF digits", Input: " 52998224725 ", Expected: "52998224725", Type: DocumentTypeCPF},
+		{Name: "Should reject CPF with wrong check digit", Input: "529.982.247-24", Error: true},
+		{Name: "Should reject CPF with repeated digits", Input: "111.111.111-11", Error: true},
+		{Name: "Should accept formatted CNPJ", Input: "11.222.333/0001-81", Expected: "11222333000181", Type: DocumentTypeCNPJ},
+		{Name: "Should reject CNPJ with wrong check digit", Input: "11.222.333/0001-80", Error: true},
+		{Name: "Should reject CNPJ with repeated digits", Input: "00000000000000", Error: true},
+		{Name: "Should accept alphanumeric CNPJ", Input: "12.ABC.345/01DE-35", Expected: "12ABC34501DE35", Type: DocumentTypeCNPJ},
+		{Name: "Should uppercase alphanumeric CNPJ", Input: "12.abc.345/01de-35", Expected: "12ABC34501DE35", Type: DocumentTypeCNPJ},
+		{Name: "Should reject alphanumeric CNPJ with wrong check digit", Input: "12.ABC.345/01DE-34", Error: true},
+		{Name: "Should reject letters in check digits", Input: "12ABC34501DE3A", Error: true},
+		{Name: "Should reject letters in CPF", Input: "5299822472A", Error: true},
+		{Name: "Should reject symbols", Input: "11*222*333*0001*81", Error: true},
+		{Name: "Should reject other lengths", Input: "1234567890", Error: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := ParseDocument(testCase.Input)
+			if testCase.Error {
+				if !errors.Is(err, ErrInvalidDocument) {
+					t.Errorf("ParseDocument(%q) error = %v, expected ErrInvalidDocument", testCase.Input, err)
+				}
+				return
+			}
+			if err != nil || got != testCase.Expected || got.Type() != testCase.Type {
+				t.Errorf("ParseDocument(%q) = %s (%s), %v, expected %s", testCase.Input, got, got.Type(), err, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestDocumentFormat(t *testing.T) {
+	testCases := []struct {
+		Document Document
+		Expected string
+		Masked   string
+	}{
+		{Document: "52998224725", Expected: "529.982.247-25", Masked: "***.982.247-**"},
+		{Document: "11222333000181", Expected: "11.222.333/0001-81", Masked: "11.222.333/****-**"},
+		{Document: "12.abc.345/01de-35", Expected: "12.ABC.345/01DE-35", Masked: "12.ABC.345/****-**"},
+		{Document: "123", Expected: "123", Masked: "***"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.Document), func(t *testing.T) {
+			if got := testCase.Document.String(); got != testCase.Expected {
+				t.Errorf("String() = %s, expected %s", got, testCase.Expected)
+			}
+			if got := testCase.Document.Masked(); got != testCase.Masked {
+				t.Errorf("Masked() = %s, expected %s", got, testCase.Masked)
+			}
+		})
+	}
+}
+
+func TestDocumentJSON(t *testing.T) {
+	var payer struct {
+		Document Document `json:"document"`
+		CPF      CPF      `json:"cpf"`
+		CNPJ     CNPJ     `json:"cnpj"`
+	}
+	payer.Document = "529.982.247-25"
+	payer.CPF = "529.982.247-25"
+	payer.CNPJ = "12.abc.345/01de-35"
+
+	data, err := json.Marshal(payer)
+	if err != nil || string(data) != `{"document":"52998224725","cpf":"52998224725","cnpj":"12ABC34501DE35"}` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"document":"11.222.333/0001-81","cpf":"***.982.247-**","cnpj":"11222333000181"}`), &payer)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if payer.Document != "11222333000181" || payer.CPF != "***982247**" || payer.CNPJ.Root() != "11222333" {
+		t.Errorf("Unmarshal() = %+v", payer)
+	}
+	if cnpj, ok := payer.Document.CNPJ(); !ok || !cnpj.Valid() {
+		t.Errorf("CNPJ() = %s, %v", cnpj, ok)
+	}
+	if _, ok := payer.Document.CPF(); ok {
+		t.Error("CPF() of a CNPJ succeeded")
+	}
+	if payer.CPF.Valid() {
+		t.Error("masked CPF is valid")
+	}
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Webhook event types sent by Stone.
+const (
+	EventPixIncomingEntry = "pix_incoming_entry"
+	EventPixOutgoingEntry = "pix_outgoing_entry"
+	EventPixRefund        = "pix_refund"
+	EventInternalTransfer = "internal_transfer"
+	EventExternalTransfer = "external_transfer"
+	EventBoletoPaid       = "boleto_paid"
+	EventBoletoIssued     = "boleto_issued"
+	EventPaymentProcessed = "payment_processed"
+	EventPaymentFailed    = "payment_failed"
+	EventConsentGranted   = "consent_granted"
+	EventConsentRevoked   = "consent_revoked"
+)
+
+// Party identifies the counterpart of a transaction.
+type Party struct {
+	Name        string   `json:"name,omitempty"`
+	Document    Document `json:"document,omitempty"`
+	ISPB        string   `json:"ispb,omitempty"`
+	BankCode    string   `json:"bank_code,omitempty"`
+	BranchCode  string   `json:"branch_code,omitempty"`
+	AccountCode string   `json:"account_code,omitempty"`
+	AccountType string   `json:"account_type,omitempty"`
+}
+
+// PixEntryEvent is the data of EventPixIncomingEntry and EventPixOutgoingEntry.
+type PixEntryEvent struct {
+	ID            string     `json:"id"`
+	AccountID     string     `json:"account_id"`
+	EndToEndID    string     `json:"end_to_end_id"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	Amount        Money      `json:"amount"`
+	Status        string     `json:"status"`
+	Description   string     `json:"description,omitempty"`
+	Key           string     `json:"key,omitempty"`
+	CounterParty  Party      `json:"counter_party"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+// PixRefundEvent is the data of EventPixRefund.
+type PixRefundEvent struct {
+	ID                 string     `json:"id"`
+	AccountID          string     `json:"account_id"`
+	EntryID            string     `json:"entry_id"`
+	EndToEndID         string     `json:"end_to_end_id"`
+	OriginalEndToEndID string     `json:"original_end_to_end_id"`
+	Amount             Money      `json:"amount"`
+	Reason             string     `json:"reason,omitempty"`
+	Status             string     `json:"status"`
+	CreatedAt          time.Time  `json:"created_at"`
+	SettledAt          *time.Time `json:"settled_at,omitempty"`
+}
+
+// InternalTransferEvent is the data of EventInternalTransfer.
+type InternalTransferEvent struct {
+	ID              string     `json:"id"`
+	AccountID       string     `json:"account_id"`
+	TargetAccountID string     `json:"target_account_id"`
+	Amount          Money      `json:"amount"`
+	Description     string     `json:"description,omitempty"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
+
+// ExternalTransferEvent is the data of EventExternalTransfer (TED).
+type ExternalTransferEvent struct {
+	ID          string     `json:"id"`
+	AccountID   string     `json:"account_id"`
+	Amount      Money      `json:"amount"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	Target      Party      `json:"target"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// BoletoEvent is the data of EventBoletoPaid and EventBoletoIssued.
+type BoletoEvent struct {
+	ID             string     `json:"id"`
+	AccountID      string     `json:"account_id"`
+	Amount         Money      `json:"amount"`
+	PaidAmount     Money      `json:"paid_amount,omitempty"`
+	Barcode        string     `json:"barcode"`
+	WritableLine   string     `json:"writable_line"`
+	OurNumber      string     `json:"our_number,omitempty"`
+	Status         string     `json:"status"`
+	ExpirationDate string     `json:"expiration_date"`
+	Payer          Party      `json:"payer"`
+	CreatedAt      time.Time  `json:"created_at"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+}
+
+// PaymentEvent is the data of EventPaymentProcessed and EventPaymentFailed, sent for barcode payments.
+type PaymentEvent struct {
+	ID            string     `json:"id"`
+	AccountID     string     `json:"account_id"`
+	Amount        Money      `json:"amount"`
+	Barcode       string     `json:"barcode"`
+	Status        string     `json:"status"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// ConsentEvent is the data of EventConsentGranted and EventConsentRevoked.
+type ConsentEvent struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"client_id"`
+	AccountID string    `json:"account_id"`
+	UserID    string    `json:"user_id,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewEventData returns a pointer to the zero data type of eventType, or nil for unknown events.
+func NewEventData(eventType string) interface{} {
+	switch eventType {
+	case EventPixIncomingEntry, EventPixOutgoingEntry:
+		return new(PixEntryEvent)
+	case EventPixRefund:
+		return new(PixRefundEvent)
+	case EventInternalTransfer:
+		return new(InternalTransferEvent)
+	case EventExternalTransfer:
+		return new(ExternalTransferEvent)
+	case EventBoletoPaid, EventBoletoIssued:
+		return new(BoletoEvent)
+	case EventPaymentProcessed, EventPaymentFailed:
+		return new(PaymentEvent)
+	case EventConsentGranted, EventConsentRevoked:
+		return new(ConsentEvent)
+	}
+	return nil
+}
+
+// DecodeData decodes the event data into its typed struct, e.g. *PixEntryEvent for EventPixIncomingEntry.
+func (e *WebhookEvent) DecodeData() (interface{}, error) {
+	data := NewEventData(e.Type)
+	if data == nil {
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+	if err := json.Unmarshal(e.Data, data); err != nil {
+		return nil, fmt.Errorf("decoding %s event data: %w", e.Type, err)
+	}
+	return data, nil
+}
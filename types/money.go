@@ -0,0 +1,212 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of BRL in integer cents, the unit used by the Stone API. Floating point numbers are never used
+// to represent it.
+type Money int64
+
+// ErrInvalidMoney is returned when a value can not be parsed as Money.
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// NewMoney returns the amount of reais and cents, e.g. NewMoney(10, 50) is R$ 10,50. The sign of reais applies to the
+// whole amount.
+func NewMoney(reais, cents int64) Money {
+	if reais < 0 {
+		return Money(reais*100 - cents)
+	}
+	return Money(reais*100 + cents)
+}
+
+// ParseMoney parses a decimal amount with up to two fraction digits. Both the Brazilian ("R$ 1.234,56") and the
+// international ("1234.56") notations are accepted.
+func ParseMoney(s string) (Money, error) {
+	v := strings.TrimSpace(s)
+	v = strings.TrimSpace(strings.TrimPrefix(v, "R$"))
+	negative := strings.HasPrefix(v, "-")
+	if negative {
+		v = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(v, "-")), "R$"))
+	}
+
+	// The last separator followed by one or two digits is the decimal one, any other is a thousands separator. A
+	// trailing separator is most likely a truncated amount.
+	intPart, fracPart := v, ""
+	if i := strings.LastIndexAny(v, ".,"); i == len(v)-1 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	} else if i >= 0 && len(v)-i-1 <= 2 {
+		intPart, fracPart = v[:i], v[i+1:]
+	}
+	intPart = strings.NewReplacer(".", "", ",", "").Replace(intPart)
+
+	if intPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+
+	reais, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	cents, _ := strconv.ParseInt(fracPart, 10, 64)
+	if reais > (math.MaxInt64-cents)/100 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+
+	m := Money(reais*100 + cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Cents returns the amount in cents.
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Reais returns the integer part of the amount and the remaining cents, both carrying the sign of m.
+func (m Money) Reais() (reais, cents int64) {
+	return int64(m) / 100, int64(m) % 100
+}
+
+func (m Money) Add(o Money) Money {
+	return m + o
+}
+
+func (m Money) Sub(o Money) Money {
+	return m - o
+}
+
+// Mul multiplies the amount by an integer factor.
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+// Percent returns the given percentage of the amount, in basis points (1% = 100), rounding half away from zero.
+func (m Money) Percent(basisPoints int64) Money {
+	v := int64(m) * basisPoints
+	if v < 0 {
+		return Money((v - 5000) / 10000)
+	}
+	return Money((v + 5000) / 10000)
+}
+
+// Split divides the amount in n parts whose sum is exactly m, distributing the remaining cents to the first parts.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parts := make([]Money, n)
+	quotient, remainder := m/Money(n), m%Money(n)
+	for i := range parts {
+		parts[i] = quotient
+		switch {
+		case remainder > 0:
+			parts[i]++
+			remainder--
+		case remainder < 0:
+			parts[i]--
+			remainder++
+		}
+	}
+	return parts
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// String formats the amount in BRL, e.g. "R$ 1.234,56" or "-R$ 0,50".
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+	}
+
+	reais, cents := v/100, v%100
+	if reais < 0 {
+		reais = -reais
+	}
+	if cents < 0 {
+		cents = -cents
+	}
+
+	digits := strconv.FormatInt(reais, 10)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, b.String(), cents)
+}
+
+// Decimal formats the amount as a plain decimal number with two fraction digits, e.g. "1234.56".
+func (m Money) Decimal() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// MarshalJSON encodes the amount as an integer number of cents.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(m), 10)), nil
+}
+
+// UnmarshalJSON decodes an integer number of cents, also accepting it quoted. Fractional numbers are rejected, since
+// they are most likely amounts in reais.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMoney, data)
+	}
+	if n == "" {
+		// null
+		return nil
+	}
+
+	v, err := strconv.ParseInt(n.String(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMoney, data)
+	}
+
+	*m = Money(v)
+	return nil
+}
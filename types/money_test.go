@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected Money
+		Error    bool
+	}{
+		{Input: "R$ 1.234,56", Expected: 123456},
+		{Input: "1234.56", Expected: 123456},
+		{Input: "1,234.5", Expected: 123450},
+		{Input: "1.234", Expected: 123400},
+		{Input: "0,07", Expected: 7},
+		{Input: "-R$ 10,00", Expected: -1000},
+		{Input: "R$ -0,50", Expected: -50},
+		{Input: "12", Expected: 1200},
+		{Input: "", Error: true},
+		{Input: "1,2,3a", Error: true},
+		{Input: "R$", Error: true},
+		{Input: "1,", Error: true},
+		{Input: "1.234.", Error: true},
+		{Input: "92233720368547758,07", Expected: math.MaxInt64},
+		{Input: "-92233720368547758,07", Expected: -math.MaxInt64},
+		{Input: "92233720368547758,08", Error: true},
+		{Input: "92233720368547759", Error: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Input, func(t *testing.T) {
+			got, err := ParseMoney(testCase.Input)
+			if testCase.Error {
+				if !errors.Is(err, ErrInvalidMoney) {
+					t.Errorf("ParseMoney(%q) error = %v, expected ErrInvalidMoney", testCase.Input, err)
+				}
+				return
+			}
+			if err != nil || got != testCase.Expected {
+				t.Errorf("ParseMoney(%q) = %d, %v, expected %d", testCase.Input, got, err, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	testCases := []struct {
+		Money    Money
+		Expected string
+		Decimal  string
+	}{
+		{Money: 0, Expected: "R$ 0,00", Decimal: "0.00"},
+		{Money: 5, Expected: "R$ 0,05", Decimal: "0.05"},
+		{Money: 123456, Expected: "R$ 1.234,56", Decimal: "1234.56"},
+		{Money: 100000000, Expected: "R$ 1.000.000,00", Decimal: "1000000.00"},
+		{Money: -50, Expected: "-R$ 0,50", Decimal: "-0.50"},
+		{Money: NewMoney(-3, 25), Expected: "-R$ 3,25", Decimal: "-3.25"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Expected, func(t *testing.T) {
+			if got := testCase.Money.String(); got != testCase.Expected {
+				t.Errorf("String() = %q, expected %q", got, testCase.Expected)
+			}
+			if got := testCase.Money.Decimal(); got != testCase.Decimal {
+				t.Errorf("Decimal() = %q, expected %q", got, testCase.Decimal)
+			}
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var v struct {
+		Amount Money `json:"amount"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":12345}`), &v); err != nil || v.Amount != 12345 {
+		t.Errorf("Unmarshal() = %d, %v", v.Amount, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"999"}`), &v); err != nil || v.Amount != 999 {
+		t.Errorf("Unmarshal() quoted = %d, %v", v.Amount, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":10.5}`), &v); !errors.Is(err, ErrInvalidMoney) {
+		t.Errorf("Unmarshal() fractional error = %v, expected ErrInvalidMoney", err)
+	}
+	for _, data := range []string{`"12`, `12"`, `""`, `"abc"`} {
+		var m Money
+		if err := m.UnmarshalJSON([]byte(data)); !errors.Is(err, ErrInvalidMoney) {
+			t.Errorf("UnmarshalJSON(%s) error = %v, expected ErrInvalidMoney", data, err)
+		}
+	}
+	if m := Money(7); m.UnmarshalJSON([]byte(`null`)) != nil || m != 7 {
+		t.Errorf("UnmarshalJSON(null) changed the amount to %d", m)
+	}
+
+	v.Amount = 4200
+	data, _ := json.Marshal(v)
+	if string(data) != `{"amount":4200}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	if got := Money(1000).Add(250).Sub(50).Mul(2); got != 2400 {
+		t.Errorf("arithmetic = %d, expected 2400", got)
+	}
+	if got := Money(1999).Percent(250); got != 50 {
+		t.Errorf("Percent(2.5%%) = %d, expected 50", got)
+	}
+
+	parts := Money(1000).Split(3)
+	if len(parts) != 3 || parts[0] != 334 || parts[1] != 333 || parts[2] != 333 {
+		t.Errorf("Split(3) = %v", parts)
+	}
+	if reais, cents := Money(-1234).Reais(); reais != -12 || cents != -34 {
+		t.Errorf("Reais() = %d, %d", reais, cents)
+	}
+}
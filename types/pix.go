@@ -0,0 +1,120 @@
+package types
+
+import "time"
+
+// PIX entry statuses. CONFIRMED, FAILED and REFUNDED are final.
+const (
+	PixStatusCreated    = "CREATED"
+	PixStatusProcessing = "PROCESSING"
+	PixStatusConfirmed  = "CONFIRMED"
+	PixStatusFailed     = "FAILED"
+	PixStatusRefunded   = "REFUNDED"
+)
+
+// PixKeyLookup is the result of a DICT key lookup: the account the key points to, to be confirmed with the payer
+// before paying.
+type PixKeyLookup struct {
+	Key     string      `json:"key"`
+	KeyType string      `json:"key_type"`
+	Account BankAccount `json:"account"`
+	// Owner holds the name and the masked document of the key owner.
+	Owner Entity `json:"owner"`
+	// EndToEndID is reserved for the payment and must be sent back in PixKeyPaymentInput.
+	EndToEndID string `json:"end_to_end_id"`
+}
+
+// PixKeyPaymentInput is a PIX payment to a DICT key, after a lookup.
+type PixKeyPaymentInput struct {
+	// AccountID is the source account.
+	AccountID string `json:"account_id"`
+	Amount    Money  `json:"amount"`
+	Key       string `json:"key"`
+	// EndToEndID is the one returned by the key lookup.
+	EndToEndID  string `json:"end_to_end_id"`
+	Description string `json:"description,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixManualPaymentInput is a PIX payment to an account given by its ISPB, branch and account code.
+type PixManualPaymentInput struct {
+	// AccountID is the source account.
+	AccountID   string      `json:"account_id"`
+	Amount      Money       `json:"amount"`
+	Beneficiary Beneficiary `json:"target"`
+	Description string      `json:"description,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixBRCodePaymentInput is a PIX payment of a BR Code, the "copia e cola" string of a PIX QR code.
+type PixBRCodePaymentInput struct {
+	// AccountID is the source account.
+	AccountID string `json:"account_id"`
+	BRCode    string `json:"brcode"`
+	// Amount is required for BR Codes without amount, and must be zero otherwise.
+	Amount      Money  `json:"amount,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixEntry is a PIX payment sent or received by an account.
+type PixEntry struct {
+	ID            string     `json:"id"`
+	AccountID     string     `json:"account_id"`
+	EndToEndID    string     `json:"end_to_end_id"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	Amount        Money      `json:"amount"`
+	Status        string     `json:"status"`
+	Description   string     `json:"description,omitempty"`
+	Key           string     `json:"key,omitempty"`
+	CounterParty  Party      `json:"counter_party"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+// Final reports whether the entry reached a status that will not change anymore, except by a refund.
+func (e PixEntry) Final() bool {
+	switch e.Status {
+	case PixStatusConfirmed, PixStatusFailed, PixStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// PIX refund reason codes defined by the Central Bank.
+const (
+	// RefundReasonBankError refunds a payment made by mistake of the bank.
+	RefundReasonBankError = "BE08"
+	// RefundReasonFraud refunds a payment suspected of fraud.
+	RefundReasonFraud = "FR01"
+	// RefundReasonRequested refunds a payment at the request of the receiver.
+	RefundReasonRequested = "MD06"
+	// RefundReasonCashout refunds the cash of a PIX Saque or PIX Troco that was not delivered.
+	RefundReasonCashout = "SL02"
+)
+
+// PixRefundInput refunds a received PIX payment, totally or partially.
+type PixRefundInput struct {
+	Amount Money `json:"amount"`
+	// Reason is one of the RefundReason codes.
+	Reason string `json:"reason"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixRefund is the refund, total or partial, of a received PIX payment.
+type PixRefund struct {
+	ID      string `json:"id"`
+	EntryID string `json:"entry_id"`
+	// EndToEndID identifies the refund, OriginalEndToEndID the refunded payment.
+	EndToEndID         string     `json:"end_to_end_id"`
+	OriginalEndToEndID string     `json:"original_end_to_end_id"`
+	Amount             Money      `json:"amount"`
+	Reason             string     `json:"reason"`
+	Status             string     `json:"status"`
+	CreatedAt          time.Time  `json:"created_at"`
+	SettledAt          *time.Time `json:"settled_at,omitempty"`
+}
@@ -0,0 +1,134 @@
+package types
+
+import "time"
+
+// PIX charge statuses.
+const (
+	PixChargeStatusActive    = "ACTIVE"
+	PixChargeStatusCompleted = "COMPLETED"
+	PixChargeStatusCanceled  = "CANCELED"
+	PixChargeStatusExpired   = "EXPIRED"
+)
+
+// Interest periods of due-date charges.
+const (
+	InterestDaily   = "daily"
+	InterestMonthly = "monthly"
+	InterestYearly  = "yearly"
+)
+
+// PixChargeInput creates an immediate charge, a dynamic PIX QR code valid for Expiration seconds.
+type PixChargeInput struct {
+	// AccountID is the receiving account.
+	AccountID string `json:"account_id"`
+	// Key is the DICT key of the receiving account.
+	Key    string `json:"key"`
+	Amount Money  `json:"amount"`
+	// Expiration is the validity of the charge in seconds. Stone applies its default when zero.
+	Expiration int `json:"expiration,omitempty"`
+	// TxID identifies the charge for the receiver. Stone generates it when empty.
+	TxID        string    `json:"transaction_id,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Payer       *PixPayer `json:"payer,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixDueChargeInput creates a due-date charge, a dynamic PIX QR code paid until DueDate plus ValidityAfterDue days,
+// with fines and interest after the due date and discounts before it.
+type PixDueChargeInput struct {
+	// AccountID is the receiving account.
+	AccountID string `json:"account_id"`
+	// Key is the DICT key of the receiving account.
+	Key     string `json:"key"`
+	Amount  Money  `json:"amount"`
+	DueDate Date   `json:"due_date"`
+	// ValidityAfterDue is the number of days the charge can still be paid after the due date.
+	ValidityAfterDue int `json:"validity_after_due,omitempty"`
+	// Payer is required in due-date charges.
+	Payer       PixPayer         `json:"payer"`
+	Fine        *ChargeFine      `json:"fine,omitempty"`
+	Interest    *ChargeInterest  `json:"interest,omitempty"`
+	Discounts   []ChargeDiscount `json:"discounts,omitempty"`
+	TxID        string           `json:"transaction_id,omitempty"`
+	Description string           `json:"description,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixPayer identifies who is expected to pay a charge.
+type PixPayer struct {
+	Name string `json:"name"`
+	// Document is the CPF or CNPJ of the payer.
+	Document Document `json:"document"`
+}
+
+// ChargeFine is charged once after the due date: either a fixed Amount or a Percentage of the amount, in basis
+// points (1% = 100).
+type ChargeFine struct {
+	Amount     Money `json:"amount,omitempty"`
+	Percentage int64 `json:"percentage,omitempty"`
+}
+
+// ChargeInterest accrues after the due date, at Percentage basis points per Period.
+type ChargeInterest struct {
+	Percentage int64  `json:"percentage"`
+	Period     string `json:"period"`
+}
+
+// ChargeDiscount applies to payments made until Until: either a fixed Amount or a Percentage of the amount, in basis
+// points.
+type ChargeDiscount struct {
+	Until      Date  `json:"until"`
+	Amount     Money `json:"amount,omitempty"`
+	Percentage int64 `json:"percentage,omitempty"`
+}
+
+// PixCharge is a dynamic PIX charge, either immediate or with a due date.
+type PixCharge struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	TxID      string `json:"transaction_id"`
+	Key       string `json:"key"`
+	Status    string `json:"status"`
+	Amount    Money  `json:"amount"`
+
+	// Location is the URL of the charge payload, the one encoded in its BR Code.
+	Location string `json:"location"`
+	// BRCode is the "copia e cola" string of the charge. It can be rendered with the brcode package.
+	BRCode string `json:"brcode"`
+
+	// Expiration is the validity of an immediate charge in seconds.
+	Expiration       int              `json:"expiration,omitempty"`
+	DueDate          *Date            `json:"due_date,omitempty"`
+	ValidityAfterDue int              `json:"validity_after_due,omitempty"`
+	Payer            *PixPayer        `json:"payer,omitempty"`
+	Fine             *ChargeFine      `json:"fine,omitempty"`
+	Interest         *ChargeInterest  `json:"interest,omitempty"`
+	Discounts        []ChargeDiscount `json:"discounts,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	PaidAt           *time.Time       `json:"paid_at,omitempty"`
+	// EndToEndID is the identifier of the payment, once paid.
+	EndToEndID string `json:"end_to_end_id,omitempty"`
+}
+
+// ExpiresAt returns when an immediate charge stops accepting payments, or the zero time for due-date charges.
+func (c PixCharge) ExpiresAt() time.Time {
+	if c.DueDate != nil || c.Expiration <= 0 {
+		return time.Time{}
+	}
+	return c.CreatedAt.Add(time.Duration(c.Expiration) * time.Second)
+}
+
+// PixChargeFilter selects the charges returned by a listing. Zero values are ignored.
+type PixChargeFilter struct {
+	Status string
+	Start  time.Time
+	End    time.Time
+
+	// Limit is the page size.
+	Limit int
+	// After resumes the listing from a cursor returned in a previous page.
+	After string
+}
@@ -0,0 +1,103 @@
+package types
+
+import "time"
+
+// PixKeyType is the type of a DICT key.
+type PixKeyType string
+
+const (
+	PixKeyCPF   PixKeyType = "cpf"
+	PixKeyCNPJ  PixKeyType = "cnpj"
+	PixKeyEmail PixKeyType = "email"
+	PixKeyPhone PixKeyType = "phone"
+	// PixKeyEVP is a random key generated by the DICT on registration.
+	PixKeyEVP PixKeyType = "evp"
+)
+
+// PixKeyStatus is the state of a key in the DICT.
+type PixKeyStatus string
+
+const (
+	// PixKeyStatusPending keys wait for the ownership of the e-mail or phone to be confirmed.
+	PixKeyStatusPending PixKeyStatus = "PENDING"
+	PixKeyStatusActive  PixKeyStatus = "ACTIVE"
+	// PixKeyStatusClaimed keys are the subject of an open claim.
+	PixKeyStatusClaimed PixKeyStatus = "CLAIMED"
+	PixKeyStatusDeleted PixKeyStatus = "DELETED"
+)
+
+// PixKeyInput registers a key for an account.
+type PixKeyInput struct {
+	AccountID string     `json:"account_id"`
+	Type      PixKeyType `json:"key_type"`
+	// Key must be empty for PixKeyEVP, since random keys are generated by the DICT.
+	Key string `json:"key,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixKey is a key registered in the DICT.
+type PixKey struct {
+	ID        string       `json:"id"`
+	AccountID string       `json:"account_id"`
+	Key       string       `json:"key"`
+	Type      PixKeyType   `json:"key_type"`
+	Status    PixKeyStatus `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	OwnedAt   *time.Time   `json:"owned_at,omitempty"`
+}
+
+// PixKeyClaimType is the kind of a claim over a key registered elsewhere.
+type PixKeyClaimType string
+
+const (
+	// ClaimPortability moves a key of the same owner from another institution.
+	ClaimPortability PixKeyClaimType = "portability"
+	// ClaimOwnership takes an e-mail or phone key registered by someone else.
+	ClaimOwnership PixKeyClaimType = "ownership"
+)
+
+// PixKeyClaimStatus is the state of a claim. CONFIRMED claims are completed by the claimer; COMPLETED and CANCELED are
+// final.
+type PixKeyClaimStatus string
+
+const (
+	ClaimStatusOpen              PixKeyClaimStatus = "OPEN"
+	ClaimStatusWaitingResolution PixKeyClaimStatus = "WAITING_RESOLUTION"
+	ClaimStatusConfirmed         PixKeyClaimStatus = "CONFIRMED"
+	ClaimStatusCanceled          PixKeyClaimStatus = "CANCELED"
+	ClaimStatusCompleted         PixKeyClaimStatus = "COMPLETED"
+)
+
+// Reasons to cancel a claim.
+const (
+	ClaimCancelUserRequested = "USER_REQUESTED"
+	ClaimCancelAccountClosed = "ACCOUNT_CLOSURE"
+	ClaimCancelFraud         = "FRAUD"
+)
+
+// PixKeyClaimInput claims a key registered at another institution or by another owner.
+type PixKeyClaimInput struct {
+	AccountID string          `json:"account_id"`
+	Key       string          `json:"key"`
+	KeyType   PixKeyType      `json:"key_type"`
+	Type      PixKeyClaimType `json:"claim_type"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// PixKeyClaim is a portability or ownership claim over a key. The account is either the claimer, which receives the
+// key, or the donor, which must confirm or cancel the claim before its resolution deadline.
+type PixKeyClaim struct {
+	ID                 string            `json:"id"`
+	AccountID          string            `json:"account_id"`
+	Key                string            `json:"key"`
+	KeyType            PixKeyType        `json:"key_type"`
+	Type               PixKeyClaimType   `json:"claim_type"`
+	Status             PixKeyClaimStatus `json:"status"`
+	Claimer            bool              `json:"claimer"`
+	ResolutionDeadline *time.Time        `json:"resolution_deadline,omitempty"`
+	CompletionDeadline *time.Time        `json:"completion_deadline,omitempty"`
+	CancelReason       string            `json:"cancel_reason,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EntryOperation is the direction of a statement entry.
+type EntryOperation string
+
+const (
+	EntryCredit EntryOperation = "credit"
+	EntryDebit  EntryOperation = "debit"
+)
+
+// Entry is a line of an account statement.
+type Entry struct {
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	Operation     EntryOperation `json:"operation"`
+	Status        string         `json:"status,omitempty"`
+	Amount        Money          `json:"amount"`
+	BalanceBefore Money          `json:"balance_before"`
+	BalanceAfter  Money          `json:"balance_after"`
+	Description   string         `json:"description,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+
+	// Details holds the type specific fields of the entry, such as the counterpart of a transfer.
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// StatementFilter selects the entries returned by the statement API. Zero values are ignored.
+type StatementFilter struct {
+	Start     time.Time
+	End       time.Time
+	Types     []string
+	Operation EntryOperation
+
+	// Limit is the page size.
+	Limit int
+	// After resumes the listing from a cursor returned in a previous StatementPage.
+	After string
+}
+
+// Cursor holds the pagination state of a statement page.
+type Cursor struct {
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// StatementPage is a page of statement entries. Storing Cursor.After allows a sync to resume where it stopped.
+type StatementPage struct {
+	Cursor Cursor  `json:"cursor"`
+	Data   []Entry `json:"data"`
+}
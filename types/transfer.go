@@ -0,0 +1,121 @@
+package types
+
+import "time"
+
+// Transfer statuses.
+const (
+	TransferStatusCreated   = "CREATED"
+	TransferStatusScheduled = "SCHEDULED"
+	TransferStatusFinished  = "FINISHED"
+	TransferStatusFailed    = "FAILED"
+	TransferStatusCanceled  = "CANCELED"
+)
+
+// InternalTransferInput is the request to transfer money between two Stone accounts.
+type InternalTransferInput struct {
+	// AccountID is the source account.
+	AccountID string `json:"account_id"`
+	Amount    Money  `json:"amount"`
+	// Target identifies the destination account by its account code.
+	Target      TransferTarget `json:"target"`
+	Description string         `json:"description,omitempty"`
+	// ScheduledTo schedules the transfer to a future date. When nil the transfer is executed right away.
+	ScheduledTo *Date `json:"scheduled_to,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// TransferTarget is the destination of a transfer.
+type TransferTarget struct {
+	Account TransferAccount `json:"account"`
+}
+
+// TransferAccount identifies an account in a transfer.
+type TransferAccount struct {
+	AccountCode string `json:"account_code"`
+	BranchCode  string `json:"branch_code,omitempty"`
+}
+
+// InternalTransfer is a transfer between two Stone accounts.
+type InternalTransfer struct {
+	ID            string         `json:"id"`
+	AccountID     string         `json:"account_id"`
+	Amount        Money          `json:"amount"`
+	Target        TransferTarget `json:"target"`
+	Description   string         `json:"description,omitempty"`
+	Status        string         `json:"status"`
+	ScheduledTo   *Date          `json:"scheduled_to,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	FinishedAt    *time.Time     `json:"finished_at,omitempty"`
+	CanceledAt    *time.Time     `json:"canceled_at,omitempty"`
+	FailureReason string         `json:"failure_reason,omitempty"`
+}
+
+// TransferFilter selects the transfers returned by a listing. Zero values are ignored.
+type TransferFilter struct {
+	Status string
+
+	// Limit is the page size.
+	Limit int
+	// After resumes the listing from a cursor returned in a previous page.
+	After string
+}
+
+// Bank account types of a TED beneficiary.
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+	AccountTypeSalary   = "salary"
+	AccountTypePayment  = "payment"
+)
+
+// ExternalTransferInput is the request to send a TED to an account at another bank.
+type ExternalTransferInput struct {
+	// AccountID is the source account.
+	AccountID   string      `json:"account_id"`
+	Amount      Money       `json:"amount"`
+	Beneficiary Beneficiary `json:"target"`
+	Description string      `json:"description,omitempty"`
+	// ScheduledTo schedules the transfer to a future date. When nil the transfer is executed right away.
+	ScheduledTo *Date `json:"scheduled_to,omitempty"`
+
+	IdempotencyKey string `json:"-"`
+}
+
+// Beneficiary is the receiver of a TED.
+type Beneficiary struct {
+	Account BankAccount `json:"account"`
+	Entity  Entity      `json:"entity"`
+}
+
+// BankAccount identifies an account at any bank. Either ISPB or BankCode must be set.
+type BankAccount struct {
+	ISPB        string `json:"institution_code,omitempty"`
+	BankCode    string `json:"bank_code,omitempty"`
+	BranchCode  string `json:"branch_code"`
+	AccountCode string `json:"account_code"`
+	AccountType string `json:"account_type"`
+}
+
+// Entity is the owner of an account.
+type Entity struct {
+	Name     string   `json:"name"`
+	Document Document `json:"document"`
+	// DocumentType is DocumentTypeCPF or DocumentTypeCNPJ. It is inferred from Document when empty.
+	DocumentType string `json:"document_type,omitempty"`
+}
+
+// ExternalTransfer is a TED sent to another bank.
+type ExternalTransfer struct {
+	ID            string      `json:"id"`
+	AccountID     string      `json:"account_id"`
+	Amount        Money       `json:"amount"`
+	Beneficiary   Beneficiary `json:"target"`
+	Description   string      `json:"description,omitempty"`
+	Status        string      `json:"status"`
+	ScheduledTo   *Date       `json:"scheduled_to,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	FinishedAt    *time.Time  `json:"finished_at,omitempty"`
+	CanceledAt    *time.Time  `json:"canceled_at,omitempty"`
+	FailureReason string      `json:"failure_reason,omitempty"`
+}
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/go-jose/go-jose/v4"
 )
 
@@ -10,3 +13,40 @@ type StonePublicKeys map[string]*jose.JSONWebKey
 func (s StonePublicKeys) Get(key string) *jose.JSONWebKey {
 	return s[key]
 }
+
+// WebhookEvent is a decrypted and verified webhook event sent by Stone.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+
+	// IssuedAt and ExpiresAt come from the iat and exp claims of the signed payload.
+	IssuedAt  *UnixTime `json:"iat,omitempty"`
+	ExpiresAt *UnixTime `json:"exp,omitempty"`
+
+	// KeyID is the kid of the Stone key that signed the event.
+	KeyID string `json:"-"`
+}
+
+// UnixTime is a time encoded as seconds since the Unix epoch, as used by JWT claims.
+type UnixTime struct {
+	time.Time
+}
+
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Unix())
+}
+
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	var secs json.Number
+	if err := json.Unmarshal(data, &secs); err != nil {
+		return err
+	}
+	f, err := secs.Float64()
+	if err != nil {
+		return err
+	}
+	t.Time = time.Unix(int64(f), 0)
+	return nil
+}
@@ -0,0 +1,139 @@
+package openbank
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+var (
+	bankCodeRegexp    = regexp.MustCompile(`^\d{3}$`)
+	ispbRegexp        = regexp.MustCompile(`^\d{8}$`)
+	branchCodeRegexp  = regexp.MustCompile(`^\d{1,4}$`)
+	accountCodeRegexp = regexp.MustCompile(`^\d{1,20}(-?[\dXx])?$`)
+)
+
+// validationErrors collects the local validation failures of a request, reported in the same shape as Stone's.
+type validationErrors []ErrorDetail
+
+func (v *validationErrors) add(msg string, path ...string) {
+	*v = append(*v, ErrorDetail{Error: msg, Path: path})
+}
+
+// err returns an error matching ErrValidation and carrying a *TransferError, or nil when nothing failed.
+func (v validationErrors) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrValidation, &TransferError{Type: "local:validation", ValidationErrors: v})
+}
+
+func validateExternalTransfer(input *types.ExternalTransferInput) error {
+	var errs validationErrors
+	errs.payment(input.AccountID, input.Amount)
+	errs.beneficiary(input.Beneficiary, false)
+
+	return errs.err()
+}
+
+// payment validates the source account and amount of a payment.
+func (v *validationErrors) payment(accountID string, amount types.Money) {
+	if accountID == "" {
+		v.add("is required", "account_id")
+	}
+	if amount <= 0 {
+		v.add("must be greater than 0", "amount")
+	}
+}
+
+// charge validates the fields shared by every PIX charge.
+func (v *validationErrors) charge(accountID, key string, amount types.Money) {
+	v.payment(accountID, amount)
+	if key == "" {
+		v.add("is required", "key")
+	}
+}
+
+func (v *validationErrors) payer(name string, document types.Document) {
+	if strings.TrimSpace(name) == "" {
+		v.add("is required", "payer", "name")
+	}
+	if !document.Valid() {
+		v.add("invalid CPF or CNPJ", "payer", "document")
+	}
+}
+
+// dueRules validates the fine, interest and discounts of a charge payable until a due date.
+func (v *validationErrors) dueRules(amount types.Money, dueDate types.Date, fine *types.ChargeFine, interest *types.ChargeInterest, discounts []types.ChargeDiscount) {
+	if f := fine; f != nil && (f.Amount < 0 || f.Percentage < 0 || f.Percentage > 10000 || (f.Amount > 0) == (f.Percentage > 0)) {
+		v.add("must have either an amount or a percentage up to 10000 basis points", "fine")
+	}
+	if i := interest; i != nil {
+		if i.Percentage <= 0 || i.Percentage > 10000 {
+			v.add("must be between 1 and 10000 basis points", "interest", "percentage")
+		}
+		switch i.Period {
+		case types.InterestDaily, types.InterestMonthly, types.InterestYearly:
+		default:
+			v.add(fmt.Sprintf("invalid period %q", i.Period), "interest", "period")
+		}
+	}
+	for n, d := range discounts {
+		path := []string{"discounts", strconv.Itoa(n)}
+		switch {
+		case (d.Amount > 0) == (d.Percentage > 0) || d.Amount < 0 || d.Percentage < 0:
+			v.add("must have either an amount or a percentage", path...)
+		case d.Amount >= amount || d.Percentage >= 10000:
+			v.add("must be less than the charge amount", path...)
+		case d.Until.IsZero() || (!dueDate.IsZero() && d.Until.After(dueDate.Time)):
+			v.add("must apply until a date not after the due date", path...)
+		}
+	}
+}
+
+// beneficiary validates the account and owner of a transfer target. PIX targets are identified by ISPB only.
+func (v *validationErrors) beneficiary(b types.Beneficiary, requireISPB bool) {
+	account := b.Account
+	switch {
+	case requireISPB && !ispbRegexp.MatchString(account.ISPB):
+		v.add("must have 8 digits", "target", "account", "institution_code")
+	case requireISPB:
+	case account.ISPB == "" && account.BankCode == "":
+		v.add("institution_code or bank_code is required", "target", "account")
+	case account.ISPB != "" && !ispbRegexp.MatchString(account.ISPB):
+		v.add("must have 8 digits", "target", "account", "institution_code")
+	case account.BankCode != "" && !bankCodeRegexp.MatchString(account.BankCode):
+		v.add("must have 3 digits", "target", "account", "bank_code")
+	}
+	if !branchCodeRegexp.MatchString(account.BranchCode) {
+		v.add("must have up to 4 digits, without check digit", "target", "account", "branch_code")
+	}
+	if !accountCodeRegexp.MatchString(account.AccountCode) {
+		v.add("must have only digits and an optional check digit", "target", "account", "account_code")
+	}
+	switch account.AccountType {
+	case types.AccountTypeChecking, types.AccountTypeSavings, types.AccountTypeSalary, types.AccountTypePayment:
+	default:
+		v.add(fmt.Sprintf("invalid account type %q", account.AccountType), "target", "account", "account_type")
+	}
+
+	entity := b.Entity
+	if strings.TrimSpace(entity.Name) == "" {
+		v.add("is required", "target", "entity", "name")
+	}
+	if !entity.Document.Valid() {
+		v.add("invalid CPF or CNPJ", "target", "entity", "document")
+	} else if entity.DocumentType != "" && entity.DocumentType != entity.Document.Type() {
+		v.add("does not match the document", "target", "entity", "document_type")
+	}
+}
+
+// inferDocumentType sets the document type of a beneficiary from its document when empty.
+func inferDocumentType(e *types.Entity) {
+	if e.DocumentType == "" {
+		e.DocumentType = e.Document.Type()
+	}
+}
@@ -0,0 +1,68 @@
+package openbank
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestValidateExternalTransfer(t *testing.T) {
+	valid := func() *types.ExternalTransferInput {
+		return &types.ExternalTransferInput{
+			AccountID: "acc-1",
+			Amount:    10000,
+			Beneficiary: types.Beneficiary{
+				Account: types.BankAccount{BankCode: "341", BranchCode: "0001", AccountCode: "12345-6", AccountType: types.AccountTypeChecking},
+				Entity:  types.Entity{Name: "Fornecedor Ltda", Document: "11.222.333/0001-81"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name         string
+		Change       func(*types.ExternalTransferInput)
+		ExpectedPath string
+	}{
+		{Name: "Should accept valid input", Change: func(*types.ExternalTransferInput) {}},
+		{Name: "Should accept ISPB instead of bank code", Change: func(i *types.ExternalTransferInput) {
+			i.Beneficiary.Account.BankCode, i.Beneficiary.Account.ISPB = "", "60701190"
+		}},
+		{Name: "Should require positive amount", Change: func(i *types.ExternalTransferInput) { i.Amount = 0 }, ExpectedPath: "amount"},
+		{Name: "Should require bank", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Account.BankCode = "" }, ExpectedPath: "target.account"},
+		{Name: "Should reject malformed bank code", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Account.BankCode = "34" }, ExpectedPath: "target.account.bank_code"},
+		{Name: "Should reject branch with check digit", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Account.BranchCode = "0001-9" }, ExpectedPath: "target.account.branch_code"},
+		{Name: "Should reject malformed account", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Account.AccountCode = "12.345" }, ExpectedPath: "target.account.account_code"},
+		{Name: "Should reject unknown account type", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Account.AccountType = "investment" }, ExpectedPath: "target.account.account_type"},
+		{Name: "Should reject invalid document", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Entity.Document = "11.222.333/0001-82" }, ExpectedPath: "target.entity.document"},
+		{Name: "Should reject mismatched document type", Change: func(i *types.ExternalTransferInput) { i.Beneficiary.Entity.DocumentType = "cpf" }, ExpectedPath: "target.entity.document_type"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			input := valid()
+			testCase.Change(input)
+
+			// Act
+			err := validateExternalTransfer(input)
+
+			// Asserts
+			if testCase.ExpectedPath == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+
+			var te *TransferError
+			if !errors.Is(err, ErrValidation) || !errors.As(err, &te) {
+				t.Fatalf("expected validation TransferError, got %v", err)
+			}
+			if len(te.ValidationErrors) != 1 || !strings.HasPrefix(te.ValidationErrors[0].String(), testCase.ExpectedPath+":") {
+				t.Errorf("validation errors = %v, expected path %s", te.ValidationErrors, testCase.ExpectedPath)
+			}
+		})
+	}
+}
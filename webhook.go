@@ -0,0 +1,221 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+const (
+	// webhookMaxBodySize limits how much of a webhook request body is read.
+	webhookMaxBodySize = 1 << 20
+
+	// webhookClockSkew is the tolerance applied when checking the expiration of events.
+	webhookClockSkew = time.Minute
+)
+
+// Errors returned by ParseWebhook. They are distinct so that callers can alert on each of them.
+var (
+	ErrWebhookMalformed        = errors.New("openbank: malformed webhook payload")
+	ErrWebhookDecryption       = errors.New("openbank: unable to decrypt webhook payload")
+	ErrWebhookUnknownKeyID     = errors.New("openbank: webhook signed with unknown key")
+	ErrWebhookInvalidSignature = errors.New("openbank: invalid webhook signature")
+	ErrWebhookExpired          = errors.New("openbank: webhook event expired")
+	ErrWebhookTimestamp        = errors.New("openbank: webhook timestamp outside the accepted window")
+)
+
+var (
+	webhookKeyAlgorithms = []jose.KeyAlgorithm{jose.RSA_OAEP_256, jose.RSA_OAEP}
+
+	webhookContentEncryption = []jose.ContentEncryption{
+		jose.A256GCM, jose.A192GCM, jose.A128GCM,
+		jose.A256CBC_HS512, jose.A192CBC_HS384, jose.A128CBC_HS256,
+	}
+
+	webhookSignatureAlgorithms = []jose.SignatureAlgorithm{
+		jose.RS256, jose.RS384, jose.RS512,
+		jose.PS256, jose.PS384, jose.PS512,
+		jose.ES256, jose.ES384, jose.ES512,
+	}
+)
+
+// WebhookHandlerFunc processes a verified webhook event. Returning an error makes the handler answer with a 5xx status,
+// so that Stone delivers the event again.
+type WebhookHandlerFunc func(ctx context.Context, event *types.WebhookEvent) error
+
+// ParseWebhook decrypts a webhook body with the client private key, or the crypto.Decrypter given to WithSigner or
+// WithDecrypter, verifies the inner signature against StonePublicKeys and decodes the event.
+//
+// The body may be either the compact JWE itself or a JSON object carrying it in the encrypted_body field.
+func (c *Client) ParseWebhook(body []byte) (*types.WebhookEvent, error) {
+	return c.parseWebhook(context.Background(), body)
+}
+
+func (c *Client) parseWebhook(ctx context.Context, body []byte) (*types.WebhookEvent, error) {
+	if c.decrypter == nil {
+		return nil, errors.New("missing private key or decrypter to decrypt webhook")
+	}
+
+	encrypted, err := jose.ParseEncrypted(webhookJWE(body), webhookKeyAlgorithms, webhookContentEncryption)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookMalformed, err)
+	}
+
+	signed, err := encrypted.Decrypt(c.webhookDecryptionKey())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookDecryption, err)
+	}
+
+	jws, err := jose.ParseSigned(string(signed), webhookSignatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookMalformed, err)
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, fmt.Errorf("%w: expected one signature, got %d", ErrWebhookMalformed, len(jws.Signatures))
+	}
+
+	kid := jws.Signatures[0].Header.KeyID
+	key := c.stonePublicKey(ctx, kid)
+	if key == nil {
+		return nil, fmt.Errorf("%w: %q", ErrWebhookUnknownKeyID, kid)
+	}
+
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookInvalidSignature, err)
+	}
+
+	var event types.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookMalformed, err)
+	}
+	event.KeyID = kid
+
+	if event.ExpiresAt != nil && time.Now().After(event.ExpiresAt.Add(webhookClockSkew)) {
+		return nil, fmt.Errorf("%w: at %s", ErrWebhookExpired, event.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if c.webhookWindow > 0 {
+		if event.IssuedAt == nil {
+			return nil, fmt.Errorf("%w: missing iat claim", ErrWebhookTimestamp)
+		}
+		if skew := time.Since(event.IssuedAt.Time); skew > c.webhookWindow || skew < -c.webhookWindow {
+			return nil, fmt.Errorf("%w: issued at %s", ErrWebhookTimestamp, event.IssuedAt.Format(time.RFC3339))
+		}
+	}
+
+	return &event, nil
+}
+
+// WebhookHandler returns an http.Handler that parses incoming webhooks with ParseWebhook and calls handle for each
+// verified event. Invalid payloads are answered with 400, signature failures with 401.
+//
+// When an EventStore is configured, events already processed are answered with 200 without calling handle, and
+// deliveries of an event still being handled are answered with 409 so that Stone tries again later.
+func (c *Client) WebhookHandler(handle WebhookHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhookMaxBodySize))
+		if err != nil {
+			c.log.Error(fmt.Errorf("reading webhook body error %s", err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		event, err := c.parseWebhook(r.Context(), body)
+		if err != nil {
+			c.log.Error(fmt.Errorf("parsing webhook error %s", err))
+			w.WriteHeader(webhookErrorStatus(err))
+			return
+		}
+
+		w.WriteHeader(c.handleWebhookEvent(r.Context(), event, handle))
+	})
+}
+
+// handleWebhookEvent calls handle at most once per event ID and returns the status to answer with. A panic in handle is
+// recovered and answered with 500, releasing the reservation of the event.
+func (c *Client) handleWebhookEvent(ctx context.Context, event *types.WebhookEvent, handle WebhookHandlerFunc) (status int) {
+	store := c.webhookEvents
+	if store != nil {
+		if event.ID == "" {
+			c.log.Error(errors.New("webhook event without id can not be deduplicated"))
+			return http.StatusBadRequest
+		}
+
+		state, err := store.Reserve(ctx, event.ID)
+		if err != nil {
+			c.log.Error(fmt.Errorf("reserving webhook %s error %s", event.ID, err))
+			return http.StatusInternalServerError
+		}
+		switch state {
+		case EventProcessed:
+			return http.StatusOK
+		case EventInProgress:
+			return http.StatusConflict
+		}
+	}
+
+	completed := false
+	defer func() {
+		if r := recover(); r != nil {
+			c.log.Error(fmt.Errorf("handling webhook %s panic %v", event.ID, r))
+			status = http.StatusInternalServerError
+		}
+		if store != nil && !completed {
+			if err := store.Release(ctx, event.ID); err != nil {
+				c.log.Error(fmt.Errorf("releasing webhook %s error %s", event.ID, err))
+			}
+		}
+	}()
+
+	if err := handle(ctx, event); err != nil {
+		c.log.Error(fmt.Errorf("handling webhook %s error %s", event.ID, err))
+		return http.StatusInternalServerError
+	}
+
+	completed = true
+	if store != nil {
+		if err := store.Complete(ctx, event.ID); err != nil {
+			c.log.Error(fmt.Errorf("completing webhook %s error %s", event.ID, err))
+		}
+	}
+	return http.StatusOK
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWebhookUnknownKeyID), errors.Is(err, ErrWebhookInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrWebhookMalformed), errors.Is(err, ErrWebhookDecryption),
+		errors.Is(err, ErrWebhookExpired), errors.Is(err, ErrWebhookTimestamp):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// webhookJWE extracts the compact JWE from a webhook body.
+func webhookJWE(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		var envelope struct {
+			EncryptedBody string `json:"encrypted_body"`
+		}
+		if err := json.Unmarshal(body, &envelope); err == nil && envelope.EncryptedBody != "" {
+			return envelope.EncryptedBody
+		}
+	}
+	return string(body)
+}
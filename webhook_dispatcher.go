@@ -0,0 +1,108 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// WebhookDispatcher routes webhook events to the handlers registered for their type. Its Dispatch method is a
+// WebhookHandlerFunc, so it plugs directly into Client.WebhookHandler.
+type WebhookDispatcher struct {
+	m        sync.RWMutex
+	handlers map[string]WebhookHandlerFunc
+	fallback WebhookHandlerFunc
+}
+
+// NewWebhookDispatcher creates a dispatcher without handlers. Events without a handler are acknowledged and dropped
+// until a fallback is set with HandleUnknown.
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{handlers: make(map[string]WebhookHandlerFunc)}
+}
+
+// Handle registers h for events of eventType, replacing any previous handler.
+func (d *WebhookDispatcher) Handle(eventType string, h WebhookHandlerFunc) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.handlers[eventType] = h
+}
+
+// HandleUnknown registers h for events without a handler, including event types unknown to this library.
+func (d *WebhookDispatcher) HandleUnknown(h WebhookHandlerFunc) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.fallback = h
+}
+
+// Dispatch calls the handler registered for the event type, or the fallback.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event *types.WebhookEvent) error {
+	d.m.RLock()
+	h, ok := d.handlers[event.Type]
+	if !ok {
+		h = d.fallback
+	}
+	d.m.RUnlock()
+
+	if h == nil {
+		return nil
+	}
+	return h(ctx, event)
+}
+
+// HandleEvent registers a handler that receives the event data decoded into T.
+func HandleEvent[T any](d *WebhookDispatcher, eventType string, h func(ctx context.Context, event *types.WebhookEvent, data *T) error) {
+	d.Handle(eventType, func(ctx context.Context, event *types.WebhookEvent) error {
+		data := new(T)
+		if err := json.Unmarshal(event.Data, data); err != nil {
+			return fmt.Errorf("decoding %s event %s: %w", event.Type, event.ID, err)
+		}
+		return h(ctx, event, data)
+	})
+}
+
+func (d *WebhookDispatcher) OnPixIncomingEntry(h func(context.Context, *types.WebhookEvent, *types.PixEntryEvent) error) {
+	HandleEvent(d, types.EventPixIncomingEntry, h)
+}
+
+func (d *WebhookDispatcher) OnPixOutgoingEntry(h func(context.Context, *types.WebhookEvent, *types.PixEntryEvent) error) {
+	HandleEvent(d, types.EventPixOutgoingEntry, h)
+}
+
+func (d *WebhookDispatcher) OnPixRefund(h func(context.Context, *types.WebhookEvent, *types.PixRefundEvent) error) {
+	HandleEvent(d, types.EventPixRefund, h)
+}
+
+func (d *WebhookDispatcher) OnInternalTransfer(h func(context.Context, *types.WebhookEvent, *types.InternalTransferEvent) error) {
+	HandleEvent(d, types.EventInternalTransfer, h)
+}
+
+func (d *WebhookDispatcher) OnExternalTransfer(h func(context.Context, *types.WebhookEvent, *types.ExternalTransferEvent) error) {
+	HandleEvent(d, types.EventExternalTransfer, h)
+}
+
+func (d *WebhookDispatcher) OnBoletoPaid(h func(context.Context, *types.WebhookEvent, *types.BoletoEvent) error) {
+	HandleEvent(d, types.EventBoletoPaid, h)
+}
+
+func (d *WebhookDispatcher) OnBoletoIssued(h func(context.Context, *types.WebhookEvent, *types.BoletoEvent) error) {
+	HandleEvent(d, types.EventBoletoIssued, h)
+}
+
+func (d *WebhookDispatcher) OnPaymentProcessed(h func(context.Context, *types.WebhookEvent, *types.PaymentEvent) error) {
+	HandleEvent(d, types.EventPaymentProcessed, h)
+}
+
+func (d *WebhookDispatcher) OnPaymentFailed(h func(context.Context, *types.WebhookEvent, *types.PaymentEvent) error) {
+	HandleEvent(d, types.EventPaymentFailed, h)
+}
+
+func (d *WebhookDispatcher) OnConsentGranted(h func(context.Context, *types.WebhookEvent, *types.ConsentEvent) error) {
+	HandleEvent(d, types.EventConsentGranted, h)
+}
+
+func (d *WebhookDispatcher) OnConsentRevoked(h func(context.Context, *types.WebhookEvent, *types.ConsentEvent) error) {
+	HandleEvent(d, types.EventConsentRevoked, h)
+}
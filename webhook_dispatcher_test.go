@@ -0,0 +1,81 @@
+package openbank
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+func TestWebhookDispatcher(t *testing.T) {
+	var got []string
+
+	d := NewWebhookDispatcher()
+	d.OnPixIncomingEntry(func(ctx context.Context, event *types.WebhookEvent, entry *types.PixEntryEvent) error {
+		got = append(got, "pix:"+entry.EndToEndID)
+		return nil
+	})
+	d.OnBoletoPaid(func(ctx context.Context, event *types.WebhookEvent, boleto *types.BoletoEvent) error {
+		got = append(got, "boleto:"+boleto.Barcode)
+		return nil
+	})
+
+	testCases := []struct {
+		Name          string
+		Event         types.WebhookEvent
+		ExpectedError bool
+		Expected      []string
+	}{
+		{
+			Name:     "Should decode PIX incoming entry",
+			Event:    types.WebhookEvent{ID: "1", Type: types.EventPixIncomingEntry, Data: json.RawMessage(`{"end_to_end_id":"E123","amount":150}`)},
+			Expected: []string{"pix:E123"},
+		},
+		{
+			Name:     "Should decode boleto paid",
+			Event:    types.WebhookEvent{ID: "2", Type: types.EventBoletoPaid, Data: json.RawMessage(`{"barcode":"34191"}`)},
+			Expected: []string{"boleto:34191"},
+		},
+		{
+			Name:  "Should ignore events without handler",
+			Event: types.WebhookEvent{ID: "3", Type: "something_new", Data: json.RawMessage(`{}`)},
+		},
+		{
+			Name:          "Should fail on malformed data",
+			Event:         types.WebhookEvent{ID: "4", Type: types.EventPixIncomingEntry, Data: json.RawMessage(`{"amount":"x"}`)},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got = nil
+
+			err := d.Dispatch(context.Background(), &testCase.Event)
+
+			if testCase.ExpectedError != (err != nil) {
+				t.Errorf("Dispatch() error = %v, expected error %v", err, testCase.ExpectedError)
+			}
+			if len(got) != len(testCase.Expected) || (len(got) > 0 && got[0] != testCase.Expected[0]) {
+				t.Errorf("handled %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcherFallback(t *testing.T) {
+	var fallback string
+
+	d := NewWebhookDispatcher()
+	d.HandleUnknown(func(ctx context.Context, event *types.WebhookEvent) error {
+		fallback = event.Type
+		return nil
+	})
+
+	_ = d.Dispatch(context.Background(), &types.WebhookEvent{Type: "something_new"})
+
+	if fallback != "something_new" {
+		t.Errorf("fallback received %q, expected something_new", fallback)
+	}
+}
@@ -0,0 +1,244 @@
+package openbank
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stone-payments/merchant-go-stone-openbank/v3/types"
+)
+
+// webhookFixture holds the keys needed to build webhooks the way Stone sends them.
+type webhookFixture struct {
+	client   *Client
+	stoneKey *rsa.PrivateKey
+	kid      string
+}
+
+func newWebhookFixture(t *testing.T) *webhookFixture {
+	t.Helper()
+
+	c, err := NewClient(WithPEMPrivateKey(testPEMPrivateKey(t)))
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	stoneKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	f := &webhookFixture{client: c, stoneKey: stoneKey, kid: "stone-key-1"}
+	c.StonePublicKeys[f.kid] = &jose.JSONWebKey{Key: &stoneKey.PublicKey, KeyID: f.kid, Algorithm: string(jose.RS256), Use: "sig"}
+	return f
+}
+
+// body signs payload with signingKey under kid and encrypts the result to the client public key.
+func (f *webhookFixture) body(t *testing.T, payload interface{}, signingKey *rsa.PrivateKey, kid string) []byte {
+	t.Helper()
+
+	data, _ := json.Marshal(payload)
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: signingKey, KeyID: kid}}, nil)
+	if err != nil {
+		t.Fatalf("error creating signer: %v", err)
+	}
+	jws, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("error signing payload: %v", err)
+	}
+	signed, _ := jws.CompactSerialize()
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM,
+		jose.Recipient{Algorithm: jose.RSA_OAEP_256, Key: &f.client.privateKey.PublicKey}, nil)
+	if err != nil {
+		t.Fatalf("error creating encrypter: %v", err)
+	}
+	jwe, err := encrypter.Encrypt([]byte(signed))
+	if err != nil {
+		t.Fatalf("error encrypting payload: %v", err)
+	}
+	encrypted, _ := jwe.CompactSerialize()
+
+	body, _ := json.Marshal(map[string]string{"encrypted_body": encrypted})
+	return body
+}
+
+func testWebhookPayload(id string, exp time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "pix_incoming_entry",
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"iat":        time.Now().Unix(),
+		"exp":        exp.Unix(),
+		"data":       map[string]interface{}{"amount": 1000},
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	f := newWebhookFixture(t)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	valid := testWebhookPayload("evt-1", time.Now().Add(time.Hour))
+
+	testCases := []struct {
+		Name          string
+		Body          []byte
+		ExpectedError error
+	}{
+		{
+			Name: "Should parse a valid webhook",
+			Body: f.body(t, valid, f.stoneKey, f.kid),
+		},
+		{
+			Name:          "Should reject unknown kid",
+			Body:          f.body(t, valid, f.stoneKey, "rotated-key"),
+			ExpectedError: ErrWebhookUnknownKeyID,
+		},
+		{
+			Name:          "Should reject invalid signature",
+			Body:          f.body(t, valid, otherKey, f.kid),
+			ExpectedError: ErrWebhookInvalidSignature,
+		},
+		{
+			Name:          "Should reject expired event",
+			Body:          f.body(t, testWebhookPayload("evt-2", time.Now().Add(-time.Hour)), f.stoneKey, f.kid),
+			ExpectedError: ErrWebhookExpired,
+		},
+		{
+			Name:          "Should reject malformed body",
+			Body:          []byte(`{"encrypted_body":"not-a-jwe"}`),
+			ExpectedError: ErrWebhookMalformed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Act
+			event, err := f.client.ParseWebhook(testCase.Body)
+
+			// Asserts
+			if testCase.ExpectedError != nil {
+				if !errors.Is(err, testCase.ExpectedError) {
+					t.Errorf("expected %v, got %v", testCase.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.ID != "evt-1" || event.Type != "pix_incoming_entry" || event.KeyID != f.kid {
+				t.Errorf("unexpected event %+v", event)
+			}
+			if string(event.Data) != `{"amount":1000}` {
+				t.Errorf("event data = %s", event.Data)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	f := newWebhookFixture(t)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	payload := testWebhookPayload("evt-1", time.Now().Add(time.Hour))
+
+	testCases := []struct {
+		Name           string
+		Body           []byte
+		HandlerError   error
+		ExpectedStatus int
+		ExpectedCalls  int
+	}{
+		{Name: "Should call handler for valid event", Body: f.body(t, payload, f.stoneKey, f.kid), ExpectedStatus: 200, ExpectedCalls: 1},
+		{Name: "Should answer 401 for invalid signature", Body: f.body(t, payload, otherKey, f.kid), ExpectedStatus: 401},
+		{Name: "Should answer 400 for malformed body", Body: []byte("garbage"), ExpectedStatus: 400},
+		{Name: "Should answer 500 when handler fails", Body: f.body(t, payload, f.stoneKey, f.kid), HandlerError: errors.New("boom"), ExpectedStatus: 500, ExpectedCalls: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			calls := 0
+			handler := f.client.WebhookHandler(func(ctx context.Context, event *types.WebhookEvent) error {
+				calls++
+				return testCase.HandlerError
+			})
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(testCase.Body)))
+
+			if rec.Code != testCase.ExpectedStatus {
+				t.Errorf("status = %d, expected %d", rec.Code, testCase.ExpectedStatus)
+			}
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("handler calls = %d, expected %d", calls, testCase.ExpectedCalls)
+			}
+		})
+	}
+}
+
+func TestParseWebhookWithSigner(t *testing.T) {
+	f := newWebhookFixture(t)
+	body := f.body(t, testWebhookPayload("evt-1", time.Now().Add(time.Hour)), f.stoneKey, f.kid)
+
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(f.client.privateKey)})
+	signer, err := NewLocalSigner(pemKey)
+	if err != nil {
+		t.Fatalf("NewLocalSigner() error = %v", err)
+	}
+
+	testCases := []struct {
+		Name string
+		Opt  ClientOpt
+	}{
+		{Name: "Should decrypt with an RSA signer", Opt: WithSigner(signer)},
+		{Name: "Should decrypt with a decrypter", Opt: WithDecrypter(signer.(crypto.Decrypter))},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// Arrange
+			c, err := NewClient(testCase.Opt)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.StonePublicKeys = f.client.StonePublicKeys
+
+			// Act
+			event, err := c.ParseWebhook(body)
+
+			// Asserts
+			if err != nil || event.ID != "evt-1" {
+				t.Errorf("ParseWebhook() = %+v, %v", event, err)
+			}
+		})
+	}
+}
+
+func TestWithDecrypterRequiresRSA(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if _, err := NewClient(WithDecrypter(ecdsaDecrypter{key})); err == nil {
+		t.Error("NewClient() with an ECDSA decrypter succeeded")
+	}
+}
+
+type ecdsaDecrypter struct {
+	*ecdsa.PrivateKey
+}
+
+func (ecdsaDecrypter) Decrypt(io.Reader, []byte, crypto.DecrypterOpts) ([]byte, error) {
+	return nil, errors.New("not supported")
+}